@@ -0,0 +1,295 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-git-client-window/models"
+)
+
+// BlameFunc 增量 Blame 回调，commit 仅在该提交首次出现时非 nil
+type BlameFunc func(group models.GitBlameGroup, commit *models.GitBlameCommit)
+
+// GetBlame 获取文件 blame 信息
+func (s *GitCoreService) GetBlame(repoPath, filename string) ([]models.GitBlameLine, error) {
+	blame, err := s.BlameFile(repoPath, filename, models.GitBlameOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]models.GitBlameLine, 0, len(blame.Lines))
+	for _, line := range blame.Lines {
+		commit := blame.Commits[line.Hash]
+		line.Author = commit.Author
+		line.Date = formatGitTime(commit.AuthorTime, commit.AuthorTZ)
+		line.Message = commit.Summary
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+// BlameFile 获取文件的结构化 blame 结果，提交信息按哈希去重
+func (s *GitCoreService) BlameFile(repoPath, filename string, opts models.GitBlameOptions) (*models.GitBlame, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+	if err := checkRevisionArgs([]string{opts.Revision}); err != nil {
+		return nil, err
+	}
+
+	result, err := runGit(repoPath, blameArgs(filename, opts, "--porcelain")...)
+	if err != nil {
+		return nil, err
+	}
+
+	blame, err := ParseBlamePorcelain(result.Stdout)
+	if err != nil {
+		return nil, err
+	}
+	blame.Filename = filename
+	blame.Revision = opts.Revision
+
+	return blame, nil
+}
+
+// BlameIncremental 以 --incremental 方式 blame，适合大文件边解析边展示
+func (s *GitCoreService) BlameIncremental(ctx context.Context, repoPath, filename string, opts models.GitBlameOptions, onGroup BlameFunc) error {
+	if strings.TrimSpace(repoPath) == "" {
+		return fmt.Errorf("path cannot be empty")
+	}
+	if err := checkRevisionArgs([]string{opts.Revision}); err != nil {
+		return err
+	}
+
+	parser := newBlameIncrementalParser(onGroup)
+	_, err := RunGitCommand(ctx, GitCommandOptions{
+		Dir:    repoPath,
+		Args:   blameArgs(filename, opts, "--incremental"),
+		Stdout: &lineWriter{onLine: parser.feed},
+		// 结果已由 parser 逐组处理，不再在内存中保留完整输出
+		StreamStdout: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	return parser.err
+}
+
+// blameArgs 根据选项生成 blame 参数
+func blameArgs(filename string, opts models.GitBlameOptions, format string) []string {
+	args := []string{"blame", format}
+	if opts.IgnoreWhitespace {
+		args = append(args, "-w")
+	}
+	if opts.DetectMoves {
+		args = append(args, "-M")
+	}
+	if opts.DetectCopies {
+		args = append(args, "-C")
+	}
+	if opts.IgnoreRevsFile != "" {
+		args = append(args, "--ignore-revs-file", opts.IgnoreRevsFile)
+	}
+	if opts.StartLine > 0 || opts.EndLine > 0 {
+		start, end := strconv.Itoa(max(opts.StartLine, 1)), ""
+		if opts.EndLine > 0 {
+			end = strconv.Itoa(opts.EndLine)
+		}
+		args = append(args, "-L", start+","+end)
+	}
+	if opts.Revision != "" {
+		args = append(args, opts.Revision)
+	}
+	return append(args, "--", filename)
+}
+
+// ParseBlamePorcelain 解析 git blame --porcelain 的输出
+func ParseBlamePorcelain(output string) (*models.GitBlame, error) {
+	commits := make(map[string]*models.GitBlameCommit)
+	lines := []models.GitBlameLine{}
+
+	var current *models.GitBlameLine
+	var commit *models.GitBlameCommit
+	groupFilename := ""
+
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "\t") {
+			if current == nil {
+				return nil, fmt.Errorf("blame content without header: %q", line)
+			}
+			current.Content = line[1:]
+			if current.Filename == "" {
+				current.Filename = groupFilename
+			}
+			lines = append(lines, *current)
+			current = nil
+			continue
+		}
+		if line == "" {
+			continue
+		}
+
+		if current == nil {
+			// <hash> <orig-line> <final-line> [<lines-in-group>]
+			fields := strings.Fields(line)
+			if len(fields) < 3 {
+				return nil, fmt.Errorf("invalid blame header: %q", line)
+			}
+			hash := fields[0]
+			origLine, _ := strconv.Atoi(fields[1])
+			finalLine, _ := strconv.Atoi(fields[2])
+			if commits[hash] == nil {
+				commits[hash] = &models.GitBlameCommit{Hash: hash}
+			}
+			commit = commits[hash]
+			current = &models.GitBlameLine{Line: finalLine, OrigLine: origLine, Hash: hash}
+			if len(fields) >= 4 {
+				// 新的一组开始，filename 头会重新给出
+				groupFilename = ""
+			}
+			continue
+		}
+
+		key, value, _ := strings.Cut(line, " ")
+		if key == "filename" {
+			groupFilename = unquoteGitPath(value)
+			current.Filename = groupFilename
+			continue
+		}
+		applyBlameHeader(commit, key, value)
+	}
+
+	blame := &models.GitBlame{
+		Commits: make(map[string]models.GitBlameCommit, len(commits)),
+		Lines:   lines,
+	}
+	for hash, c := range commits {
+		blame.Commits[hash] = *c
+	}
+
+	return blame, nil
+}
+
+// blameIncrementalParser 逐行解析 git blame --incremental 的输出
+type blameIncrementalParser struct {
+	onGroup BlameFunc
+	seen    map[string]*models.GitBlameCommit
+	group   *models.GitBlameGroup
+	commit  *models.GitBlameCommit
+	isNew   bool
+	err     error
+}
+
+// newBlameIncrementalParser 创建增量解析器
+func newBlameIncrementalParser(onGroup BlameFunc) *blameIncrementalParser {
+	return &blameIncrementalParser{onGroup: onGroup, seen: make(map[string]*models.GitBlameCommit)}
+}
+
+// feed 处理一行输出，每组以 filename 行结束
+func (p *blameIncrementalParser) feed(line string) {
+	if p.err != nil || line == "" {
+		return
+	}
+
+	if p.group == nil {
+		// <hash> <orig-line> <final-line> <lines-in-group>
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			p.err = fmt.Errorf("invalid blame header: %q", line)
+			return
+		}
+		group := models.GitBlameGroup{Hash: fields[0]}
+		group.OrigLine, _ = strconv.Atoi(fields[1])
+		group.FinalLine, _ = strconv.Atoi(fields[2])
+		group.NumLines, _ = strconv.Atoi(fields[3])
+		p.group = &group
+
+		p.commit, p.isNew = p.seen[group.Hash], false
+		if p.commit == nil {
+			p.commit = &models.GitBlameCommit{Hash: group.Hash}
+			p.seen[group.Hash] = p.commit
+			p.isNew = true
+		}
+		return
+	}
+
+	key, value, _ := strings.Cut(line, " ")
+	if key != "filename" {
+		applyBlameHeader(p.commit, key, value)
+		return
+	}
+
+	p.group.Filename = unquoteGitPath(value)
+	var commit *models.GitBlameCommit
+	if p.isNew {
+		c := *p.commit
+		commit = &c
+	}
+	p.onGroup(*p.group, commit)
+	p.group = nil
+}
+
+// applyBlameHeader 将 porcelain 头信息写入提交
+func applyBlameHeader(commit *models.GitBlameCommit, key, value string) {
+	switch key {
+	case "author":
+		commit.Author = value
+	case "author-mail":
+		commit.AuthorMail = strings.Trim(value, "<>")
+	case "author-time":
+		commit.AuthorTime, _ = strconv.ParseInt(value, 10, 64)
+	case "author-tz":
+		commit.AuthorTZ = value
+	case "committer":
+		commit.Committer = value
+	case "committer-mail":
+		commit.CommitterMail = strings.Trim(value, "<>")
+	case "committer-time":
+		commit.CommitterTime, _ = strconv.ParseInt(value, 10, 64)
+	case "committer-tz":
+		commit.CommitterTZ = value
+	case "summary":
+		commit.Summary = value
+	case "boundary":
+		commit.Boundary = true
+	case "previous":
+		// previous <hash> <filename>
+		hash, filename, _ := strings.Cut(value, " ")
+		commit.Previous = hash
+		commit.PreviousFilename = unquoteGitPath(filename)
+	}
+}
+
+// formatGitTime 按提交时区格式化时间戳，格式与 --date=iso 一致
+func formatGitTime(unix int64, tz string) string {
+	if unix == 0 {
+		return ""
+	}
+	t := time.Unix(unix, 0)
+	if loc, ok := parseGitTimezone(tz); ok {
+		t = t.In(loc)
+	}
+	return t.Format("2006-01-02 15:04:05 -0700")
+}
+
+// parseGitTimezone 解析 +0800 形式的时区
+func parseGitTimezone(tz string) (*time.Location, bool) {
+	if len(tz) != 5 || (tz[0] != '+' && tz[0] != '-') {
+		return nil, false
+	}
+	hours, err1 := strconv.Atoi(tz[1:3])
+	minutes, err2 := strconv.Atoi(tz[3:5])
+	if err1 != nil || err2 != nil {
+		return nil, false
+	}
+	offset := hours*3600 + minutes*60
+	if tz[0] == '-' {
+		offset = -offset
+	}
+	return time.FixedZone(tz, offset), true
+}
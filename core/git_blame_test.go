@@ -0,0 +1,120 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-git-client-window/models"
+)
+
+const samplePorcelainBlame = "" +
+	"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa 1 1 2\n" +
+	"author Alice\n" +
+	"author-mail <alice@example.com>\n" +
+	"author-time 1700000000\n" +
+	"author-tz +0800\n" +
+	"committer Alice\n" +
+	"committer-mail <alice@example.com>\n" +
+	"committer-time 1700000000\n" +
+	"committer-tz +0800\n" +
+	"summary first commit\n" +
+	"boundary\n" +
+	"filename old.txt\n" +
+	"\tline one\n" +
+	"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa 2 2\n" +
+	"\tline two\n" +
+	"bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb 3 3 1\n" +
+	"author Bob\n" +
+	"author-mail <bob@example.com>\n" +
+	"author-time 1700003600\n" +
+	"author-tz -0130\n" +
+	"committer Bob\n" +
+	"committer-mail <bob@example.com>\n" +
+	"committer-time 1700003600\n" +
+	"committer-tz -0130\n" +
+	"summary second commit\n" +
+	"previous aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa old.txt\n" +
+	"filename new.txt\n" +
+	"\t\tindented line\n"
+
+func TestParseBlamePorcelain(t *testing.T) {
+	blame, err := ParseBlamePorcelain(samplePorcelainBlame)
+	require.NoError(t, err)
+
+	require.Len(t, blame.Lines, 3)
+	assert.Len(t, blame.Commits, 2)
+
+	assert.Equal(t, models.GitBlameLine{Line: 2, OrigLine: 2, Filename: "old.txt", Hash: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", Content: "line two"}, blame.Lines[1])
+	assert.Equal(t, "\tindented line", blame.Lines[2].Content)
+	assert.Equal(t, "new.txt", blame.Lines[2].Filename)
+
+	first := blame.Commits["aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"]
+	assert.Equal(t, "Alice", first.Author)
+	assert.Equal(t, "alice@example.com", first.AuthorMail)
+	assert.Equal(t, int64(1700000000), first.AuthorTime)
+	assert.True(t, first.Boundary)
+
+	second := blame.Commits["bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"]
+	assert.Equal(t, "second commit", second.Summary)
+	assert.Equal(t, "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", second.Previous)
+	assert.Equal(t, "old.txt", second.PreviousFilename)
+
+	assert.Equal(t, "2023-11-15 06:13:20 +0800", formatGitTime(first.AuthorTime, first.AuthorTZ))
+	assert.Equal(t, "2023-11-14 21:43:20 -0130", formatGitTime(second.AuthorTime, second.AuthorTZ))
+}
+
+func TestBlame(t *testing.T) {
+	dir := newTestRepo(t)
+	writeTestFile(t, dir, "README.md", "hello\nworld\n")
+	mustGit(t, dir, "commit", "-am", "add world")
+
+	t.Run("兼容旧接口", func(t *testing.T) {
+		lines, err := gitService.GetBlame(dir, "README.md")
+		require.NoError(t, err)
+		require.Len(t, lines, 2)
+		assert.Equal(t, 1, lines[0].Line)
+		assert.Equal(t, "hello", lines[0].Content)
+		assert.Equal(t, "Initial commit", lines[0].Message)
+		assert.Equal(t, "tester", lines[1].Author)
+		assert.Equal(t, "add world", lines[1].Message)
+		assert.NotEmpty(t, lines[1].Date)
+	})
+
+	t.Run("指定版本与行范围", func(t *testing.T) {
+		blame, err := gitService.BlameFile(dir, "README.md", models.GitBlameOptions{Revision: "HEAD~1", StartLine: 1, EndLine: 1})
+		require.NoError(t, err)
+		require.Len(t, blame.Lines, 1)
+		assert.Equal(t, "hello", blame.Lines[0].Content)
+		assert.Len(t, blame.Commits, 1)
+	})
+
+	t.Run("拒绝选项形式的版本", func(t *testing.T) {
+		_, err := gitService.BlameFile(dir, "README.md", models.GitBlameOptions{Revision: "--output=/tmp/x"})
+		assert.Error(t, err)
+		err = gitService.BlameIncremental(context.Background(), dir, "README.md", models.GitBlameOptions{Revision: "-L1,1"}, func(models.GitBlameGroup, *models.GitBlameCommit) {})
+		assert.Error(t, err)
+	})
+
+	t.Run("增量模式", func(t *testing.T) {
+		var groups []models.GitBlameGroup
+		var commits []*models.GitBlameCommit
+		err := gitService.BlameIncremental(context.Background(), dir, "README.md", models.GitBlameOptions{}, func(group models.GitBlameGroup, commit *models.GitBlameCommit) {
+			groups = append(groups, group)
+			if commit != nil {
+				commits = append(commits, commit)
+			}
+		})
+		require.NoError(t, err)
+		assert.Len(t, groups, 2)
+		assert.Len(t, commits, 2)
+		total := 0
+		for _, group := range groups {
+			total += group.NumLines
+			assert.Equal(t, "README.md", group.Filename)
+		}
+		assert.Equal(t, 2, total)
+	})
+}
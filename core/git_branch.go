@@ -0,0 +1,355 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go-git-client-window/models"
+)
+
+// branchFormat for-each-ref 输出格式，字段以 \x1f 分隔，每个分支一行
+const branchFormat = "%(refname)%1f%(HEAD)%1f%(symref)%1f%(upstream:short)%1f%(upstream:remotename)%1f" +
+	"%(upstream:track,nobracket)%1f%(objectname)%1f%(subject)%1f%(authorname)%1f%(committerdate:iso)"
+
+// listBranches 通过 for-each-ref 列出指定命名空间下的分支（refs/heads、refs/remotes）
+func listBranches(repoPath string, patterns ...string) ([]models.GitBranch, error) {
+	args := append([]string{"for-each-ref", "--format=" + branchFormat}, patterns...)
+	result, err := runGit(repoPath, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseBranchRecords(result.Stdout)
+}
+
+// ParseBranchRecords 解析 branchFormat 格式的分支记录，跳过 origin/HEAD 这类符号引用
+func ParseBranchRecords(output string) ([]models.GitBranch, error) {
+	branches := []models.GitBranch{}
+	for _, line := range strings.Split(output, "\n") {
+		if line == "" {
+			continue
+		}
+
+		parts := strings.Split(line, "\x1f")
+		if len(parts) < 10 {
+			return nil, fmt.Errorf("invalid branch record: %q", line)
+		}
+		if parts[2] != "" {
+			continue
+		}
+
+		branch := models.GitBranch{
+			Current:        parts[1] == "*",
+			Tracked:        parts[3],
+			UpstreamRemote: parts[4],
+			Hash:           parts[6],
+			Subject:        parts[7],
+			Author:         parts[8],
+			Date:           parts[9],
+		}
+		if name, ok := strings.CutPrefix(parts[0], "refs/heads/"); ok {
+			branch.Name = name
+		} else {
+			branch.Name = strings.TrimPrefix(parts[0], "refs/remotes/")
+			branch.Remote = true
+		}
+		branch.Ahead, branch.Behind, branch.UpstreamGone = parseUpstreamTrack(parts[5])
+		branches = append(branches, branch)
+	}
+
+	return branches, nil
+}
+
+// parseUpstreamTrack 解析 %(upstream:track,nobracket)，如 "ahead 1, behind 2" 或 "gone"
+func parseUpstreamTrack(track string) (ahead, behind int, gone bool) {
+	if track == "gone" {
+		return 0, 0, true
+	}
+	for _, part := range strings.Split(track, ",") {
+		fields := strings.Fields(part)
+		if len(fields) != 2 {
+			continue
+		}
+		count, _ := strconv.Atoi(fields[1])
+		switch fields[0] {
+		case "ahead":
+			ahead = count
+		case "behind":
+			behind = count
+		}
+	}
+	return ahead, behind, false
+}
+
+// SetUpstream 设置分支的上游，branch 为空时设置当前分支
+func (s *GitCoreService) SetUpstream(repoPath, branch, upstream string) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(upstream) == "" {
+		return "", fmt.Errorf("upstream cannot be empty")
+	}
+
+	args := []string{"branch", "--set-upstream-to=" + upstream}
+	if branch != "" {
+		args = append(args, branch)
+	}
+
+	output, err := ExecuteGitCommand(repoPath, args...)
+	if err != nil {
+		return "", err
+	}
+
+	return output, nil
+}
+
+// UnsetUpstream 取消分支的上游，branch 为空时取消当前分支
+func (s *GitCoreService) UnsetUpstream(repoPath, branch string) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+
+	args := []string{"branch", "--unset-upstream"}
+	if branch != "" {
+		args = append(args, branch)
+	}
+
+	output, err := ExecuteGitCommand(repoPath, args...)
+	if err != nil {
+		return "", err
+	}
+
+	return output, nil
+}
+
+// PushNewBranch 推送本地分支到远程并设置为上游（push -u）
+func (s *GitCoreService) PushNewBranch(ctx context.Context, repoPath, remote, branch string, onProgress ProgressFunc) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(branch) == "" {
+		return "", fmt.Errorf("branch cannot be empty")
+	}
+
+	result, err := runWithProgress(ctx, repoPath, onProgress, "push", "--progress", "--set-upstream", defaultRemote(remote), branch)
+	if err != nil {
+		return "", err
+	}
+
+	return progressOutput(result), nil
+}
+
+// DeleteBranch 删除本地分支，force 为 false 时使用 -d，分支未合并会返回 NotFullyMerged 错误
+func (s *GitCoreService) DeleteBranch(repoPath, branch string, force bool) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(branch) == "" {
+		return "", fmt.Errorf("branch cannot be empty")
+	}
+
+	flag := "--delete"
+	if force {
+		flag = "-D"
+	}
+
+	output, err := ExecuteGitCommand(repoPath, "branch", flag, branch)
+	if err != nil {
+		return "", err
+	}
+
+	return output, nil
+}
+
+// GetUnmergedCommits 获取分支中尚未合并到 target 的提交，target 为空时使用 HEAD，用于删除前提示
+func (s *GitCoreService) GetUnmergedCommits(repoPath, branch, target string) ([]models.GitLogEntry, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(branch) == "" {
+		return nil, fmt.Errorf("branch cannot be empty")
+	}
+	if target == "" {
+		target = "HEAD"
+	}
+
+	return compareCommits(repoPath, target, branch)
+}
+
+// RenameBranch 重命名本地分支，renameUpstream 为 true 时同时在远程创建新分支、删除旧分支并更新上游
+func (s *GitCoreService) RenameBranch(ctx context.Context, repoPath, oldName, newName string, renameUpstream bool, onProgress ProgressFunc) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(oldName) == "" || strings.TrimSpace(newName) == "" {
+		return "", fmt.Errorf("branch name cannot be empty")
+	}
+	if err := checkRevisionArgs([]string{oldName, newName}); err != nil {
+		return "", err
+	}
+
+	// 重命名前记录上游，branch -m 会保留上游配置
+	var remote, remoteRef string
+	if renameUpstream {
+		result, err := runGit(repoPath, "for-each-ref", "--format=%(upstream:remotename)%1f%(upstream:remoteref)", "refs/heads/"+oldName)
+		if err != nil {
+			return "", err
+		}
+		remote, remoteRef, _ = strings.Cut(strings.TrimSpace(result.Stdout), "\x1f")
+		// 上游为本地分支时 remotename 为 "."，推送删除会作用于本仓库的分支，只处理已配置的远程仓库
+		configured, err := isConfiguredRemote(repoPath, remote)
+		if err != nil {
+			return "", err
+		}
+		if !configured {
+			remote, remoteRef = "", ""
+		}
+	}
+	// 重命名上游需要删除旧的远程分支，在修改任何分支之前检查受保护分支规则
+	if remote != "" && remoteRef != "" {
+		target := pushTarget{destination: remoteRef}
+		if err := checkPushProtection(repoPath, models.GitPushOptions{Remote: remote}, []pushTarget{target}); err != nil {
+			return "", err
+		}
+	}
+
+	output, err := ExecuteGitCommandContext(ctx, repoPath, "branch", "--move", oldName, newName)
+	if err != nil {
+		return "", err
+	}
+	if remote == "" || remoteRef == "" {
+		return output, nil
+	}
+
+	pushed, err := s.PushNewBranch(ctx, repoPath, remote, newName, onProgress)
+	if err != nil {
+		return "", err
+	}
+	deleted, err := s.DeleteRemoteBranch(ctx, repoPath, remote, remoteRef)
+	if err != nil {
+		return "", err
+	}
+
+	return output + pushed + deleted, nil
+}
+
+// isConfiguredRemote 判断 name 是否为已配置的远程仓库名（不含 "." 或 URL）
+func isConfiguredRemote(repoPath, name string) (bool, error) {
+	if name == "" || name == "." {
+		return false, nil
+	}
+	result, err := runGit(repoPath, "remote")
+	if err != nil {
+		return false, err
+	}
+	for _, remote := range strings.Split(result.Stdout, "\n") {
+		if strings.TrimSpace(remote) == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// DeleteRemoteBranch 删除远程分支，受保护分支会返回 ProtectedBranch 错误
+func (s *GitCoreService) DeleteRemoteBranch(ctx context.Context, repoPath, remote, branch string) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(branch) == "" {
+		return "", fmt.Errorf("branch cannot be empty")
+	}
+
+	// 经由 PushWithOptions 推送删除 refspec，以便应用受保护分支规则
+	// 保留简写形式：完整的 refs/heads/... 在远程分支不存在时 git 也会报告删除成功
+	return s.PushWithOptions(ctx, repoPath, models.GitPushOptions{
+		Remote:   remote,
+		Refspecs: []string{":" + branch},
+	}, nil)
+}
+
+// ResetBranch 将分支重置到指定提交
+// 当前分支使用 reset --<mode>（默认 mixed），其他分支使用 branch --force 直接移动，mode 不生效
+func (s *GitCoreService) ResetBranch(repoPath, branch, commit, mode string) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(commit) == "" {
+		return "", fmt.Errorf("commit cannot be empty")
+	}
+
+	current, _ := s.GetCurrentBranch(repoPath)
+	if branch != "" && branch != current {
+		return ExecuteGitCommand(repoPath, "branch", "--force", branch, commit)
+	}
+
+	switch mode {
+	case "":
+		mode = models.ResetMixed
+	case models.ResetSoft, models.ResetMixed, models.ResetHard:
+	default:
+		return "", fmt.Errorf("unsupported reset mode: %s", mode)
+	}
+
+	output, err := ExecuteGitCommand(repoPath, "reset", "--"+mode, commit)
+	if err != nil {
+		return "", err
+	}
+
+	return output, nil
+}
+
+// GetMergedBranches 获取已合并到 target 的本地分支，不包括当前分支和 target 本身
+func (s *GitCoreService) GetMergedBranches(repoPath, target string) ([]models.GitBranch, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(target) == "" {
+		return nil, fmt.Errorf("target cannot be empty")
+	}
+
+	branches, err := listBranches(repoPath, "--merged="+target, "refs/heads")
+	if err != nil {
+		return nil, err
+	}
+
+	merged := []models.GitBranch{}
+	for _, branch := range branches {
+		if branch.Current || branch.Name == target {
+			continue
+		}
+		merged = append(merged, branch)
+	}
+
+	return merged, nil
+}
+
+// DeleteMergedBranches 批量删除已合并到 target 的本地分支，exclude 中的分支保留
+func (s *GitCoreService) DeleteMergedBranches(repoPath, target string, exclude []string) ([]models.GitBranchDeleteResult, error) {
+	branches, err := s.GetMergedBranches(repoPath, target)
+	if err != nil {
+		return nil, err
+	}
+
+	skip := make(map[string]bool, len(exclude))
+	for _, name := range exclude {
+		skip[name] = true
+	}
+
+	results := []models.GitBranchDeleteResult{}
+	for _, branch := range branches {
+		if skip[branch.Name] {
+			continue
+		}
+		result := models.GitBranchDeleteResult{Name: branch.Name, Deleted: true}
+		// 已确认合并到 target，但 -d 以上游或 HEAD 为准，因此使用强制删除
+		if _, err := s.DeleteBranch(repoPath, branch.Name, true); err != nil {
+			result.Deleted = false
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
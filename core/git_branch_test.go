@@ -0,0 +1,170 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-git-client-window/models"
+)
+
+func TestBranchUpstream(t *testing.T) {
+	dir := newTestRepo(t)
+	remote := t.TempDir()
+	mustGit(t, remote, "init", "--bare")
+	mustGit(t, dir, "remote", "add", "origin", remote)
+
+	_, err := gitService.PushNewBranch(context.Background(), dir, "", "master", nil)
+	require.NoError(t, err)
+
+	mustGit(t, dir, "checkout", "-b", "topic")
+	_, err = gitService.PushNewBranch(context.Background(), dir, "origin", "topic", nil)
+	require.NoError(t, err)
+	writeTestFile(t, dir, "topic.txt", "topic\n")
+	mustGit(t, dir, "add", "topic.txt")
+	mustGit(t, dir, "commit", "-m", "topic work")
+
+	branches, err := gitService.GetLocalBranches(dir)
+	require.NoError(t, err)
+	byName := map[string]models.GitBranch{}
+	for _, branch := range branches {
+		byName[branch.Name] = branch
+	}
+
+	topic := byName["topic"]
+	assert.True(t, topic.Current)
+	assert.Equal(t, "origin/topic", topic.Tracked)
+	assert.Equal(t, "origin", topic.UpstreamRemote)
+	assert.Equal(t, 1, topic.Ahead)
+	assert.Equal(t, 0, topic.Behind)
+	assert.Equal(t, "topic work", topic.Subject)
+	assert.Equal(t, "tester", topic.Author)
+	assert.NotEmpty(t, topic.Hash)
+	assert.Equal(t, "origin/master", byName["master"].Tracked)
+
+	remotes, err := gitService.GetRemoteBranches(dir)
+	require.NoError(t, err)
+	assert.Len(t, remotes, 2)
+	assert.True(t, remotes[0].Remote)
+
+	// 远程分支被删除后标记为 gone
+	mustGit(t, dir, "push", "origin", "--delete", "topic")
+	branches, err = gitService.GetBranches(dir)
+	require.NoError(t, err)
+	for _, branch := range branches {
+		if branch.Name == "topic" {
+			assert.True(t, branch.UpstreamGone)
+		}
+		assert.NotEqual(t, "origin/topic", branch.Name)
+	}
+
+	_, err = gitService.UnsetUpstream(dir, "master")
+	require.NoError(t, err)
+	_, err = gitService.SetUpstream(dir, "", "origin/master")
+	require.NoError(t, err)
+	branches, err = gitService.GetLocalBranches(dir)
+	require.NoError(t, err)
+	for _, branch := range branches {
+		switch branch.Name {
+		case "master":
+			assert.Empty(t, branch.Tracked)
+		case "topic":
+			assert.Equal(t, "origin/master", branch.Tracked)
+			assert.Equal(t, 1, branch.Ahead)
+		}
+	}
+}
+
+func TestParseUpstreamTrack(t *testing.T) {
+	ahead, behind, gone := parseUpstreamTrack("ahead 3, behind 2")
+	assert.Equal(t, []any{3, 2, false}, []any{ahead, behind, gone})
+	ahead, behind, gone = parseUpstreamTrack("behind 5")
+	assert.Equal(t, []any{0, 5, false}, []any{ahead, behind, gone})
+	_, _, gone = parseUpstreamTrack("gone")
+	assert.True(t, gone)
+}
+
+func TestBranchLifecycle(t *testing.T) {
+	dir := newConflictRepo(t)
+
+	// feature 未合并，安全删除失败
+	_, err := gitService.DeleteBranch(dir, "feature", false)
+	assert.True(t, IsGitErrorKind(err, ErrKindNotFullyMerged))
+	unmerged, err := gitService.GetUnmergedCommits(dir, "feature", "")
+	require.NoError(t, err)
+	assert.Len(t, unmerged, 2)
+
+	// 重置非当前分支与当前分支
+	_, err = gitService.ResetBranch(dir, "feature", "master~1", "")
+	require.NoError(t, err)
+	assert.Equal(t, mustGit(t, dir, "rev-parse", "master~1"), mustGit(t, dir, "rev-parse", "feature"))
+	_, err = gitService.ResetBranch(dir, "", "HEAD~1", models.ResetHard)
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", mustGit(t, dir, "show", "HEAD:README.md"))
+	_, err = gitService.ResetBranch(dir, "", "HEAD", "bogus")
+	assert.Error(t, err)
+
+	mustGit(t, dir, "branch", "done-1")
+	mustGit(t, dir, "branch", "done-2")
+	merged, err := gitService.GetMergedBranches(dir, "master")
+	require.NoError(t, err)
+	assert.Len(t, merged, 3)
+
+	results, err := gitService.DeleteMergedBranches(dir, "master", []string{"done-2"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []models.GitBranchDeleteResult{
+		{Name: "done-1", Deleted: true},
+		{Name: "feature", Deleted: true},
+	}, results)
+	branches, err := gitService.GetLocalBranches(dir)
+	require.NoError(t, err)
+	assert.Len(t, branches, 2)
+
+	_, err = gitService.DeleteBranch(dir, "done-2", false)
+	require.NoError(t, err)
+	_, err = gitService.DeleteBranch(dir, "missing", true)
+	assert.Error(t, err)
+}
+
+func TestRenameBranch(t *testing.T) {
+	dir := newTestRepo(t)
+	remote := t.TempDir()
+	mustGit(t, remote, "init", "--bare")
+	mustGit(t, dir, "remote", "add", "origin", remote)
+	mustGit(t, dir, "checkout", "-b", "old")
+	mustGit(t, dir, "push", "-u", "origin", "old")
+
+	_, err := gitService.RenameBranch(context.Background(), dir, "old", "new", true, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "new\n", mustGit(t, dir, "branch", "--show-current"))
+	assert.Equal(t, "origin/new\n", mustGit(t, dir, "rev-parse", "--abbrev-ref", "new@{upstream}"))
+	assert.Equal(t, "new\n", mustGit(t, remote, "for-each-ref", "--format=%(refname:short)", "refs/heads"))
+
+	// 仅本地重命名，上游保持不变
+	_, err = gitService.RenameBranch(context.Background(), dir, "new", "local", false, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "origin/new\n", mustGit(t, dir, "rev-parse", "--abbrev-ref", "local@{upstream}"))
+
+	_, err = gitService.DeleteRemoteBranch(context.Background(), dir, "", "new")
+	require.NoError(t, err)
+	_, err = gitService.DeleteRemoteBranch(context.Background(), dir, "origin", "new")
+	assert.True(t, IsGitErrorKind(err, ErrKindUnknownRevision))
+}
+
+func TestRenameBranchLocalUpstream(t *testing.T) {
+	dir := newTestRepo(t)
+	mustGit(t, dir, "branch", "base")
+	mustGit(t, dir, "checkout", "-b", "topic", "--track", "base")
+
+	// 上游是本地分支 base（remotename 为 "."），只重命名 topic，不能推送或删除 base
+	_, err := gitService.RenameBranch(context.Background(), dir, "topic", "renamed", true, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "renamed\n", mustGit(t, dir, "branch", "--show-current"))
+	assert.Equal(t, "base\n", mustGit(t, dir, "rev-parse", "--abbrev-ref", "renamed@{upstream}"))
+	assert.Equal(t, "base\nmaster\nrenamed\n", mustGit(t, dir, "for-each-ref", "--format=%(refname:short)", "refs/heads"))
+
+	_, err = gitService.RenameBranch(context.Background(), dir, "renamed", "--force", false, nil)
+	assert.ErrorContains(t, err, "invalid revision")
+}
@@ -0,0 +1,192 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go-git-client-window/models"
+)
+
+// commitDetailFormat 在 logFormat 前追加时间戳、时区与签名信息
+const commitDetailFormat = "%at%x1f%ai%x1f%ct%x1f%ci%x1f%G?%x1f%GS%x1f%GK%x1f" + logFormat
+
+// commitDetailExtraFields commitDetailFormat 中位于 logFormat 之前的字段数
+const commitDetailExtraFields = 7
+
+// GetCommitDetail 获取提交详情，parent 为空时与第一父提交对比，根提交与空树对比
+func (s *GitCoreService) GetCommitDetail(repoPath, commit, parent string) (*models.GitCommitDetail, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(commit) == "" {
+		return nil, fmt.Errorf("commit cannot be empty")
+	}
+	if err := checkRevisionArgs([]string{commit, parent}); err != nil {
+		return nil, err
+	}
+
+	result, err := runGit(repoPath, "log", "-1", "-z", "--format="+commitDetailFormat, commit, "--")
+	if err != nil {
+		return nil, err
+	}
+	detail, err := parseCommitDetail(strings.TrimSuffix(result.Stdout, "\x00"))
+	if err != nil {
+		return nil, err
+	}
+
+	if parent == "" && len(detail.Commit.Parents) > 0 {
+		parent = detail.Commit.Parents[0]
+	}
+	detail.ComparedParent = parent
+
+	args := []string{"diff-tree", "-r", "-z", "--no-commit-id", "--raw", "--numstat", "--find-renames"}
+	result, err = runGit(repoPath, append(args, commitDiffRange(detail.Commit.Hash, parent)...)...)
+	if err != nil {
+		return nil, err
+	}
+	if detail.Files, err = ParseRawNumstat(result.Stdout); err != nil {
+		return nil, err
+	}
+	for _, file := range detail.Files {
+		detail.Additions += file.Additions
+		detail.Deletions += file.Deletions
+	}
+
+	return detail, nil
+}
+
+// GetCommitFileDiff 获取提交中单个文件的结构化差异，parent 为空时与第一父提交对比
+// oldPath 为重命名前的路径，传入后才能识别为重命名
+func (s *GitCoreService) GetCommitFileDiff(repoPath, commit, parent, filename, oldPath string) ([]models.FileDiff, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(commit) == "" {
+		return nil, fmt.Errorf("commit cannot be empty")
+	}
+	if err := checkRevisionArgs([]string{commit, parent}); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(filename) == "" {
+		return nil, fmt.Errorf("filename cannot be empty")
+	}
+
+	if parent == "" {
+		result, err := runGit(repoPath, "log", "-1", "--format=%P", commit, "--")
+		if err != nil {
+			return nil, err
+		}
+		if parents := strings.Fields(result.Stdout); len(parents) > 0 {
+			parent = parents[0]
+		}
+	}
+
+	args := append(commitDiffRange(commit, parent), "--", filename)
+	if oldPath != "" && oldPath != filename {
+		args = append(args, oldPath)
+	}
+	return runDiff(repoPath, []string{"diff-tree", "-r", "-p", "--no-commit-id"}, args...)
+}
+
+// commitDiffRange 返回 diff-tree 的对比范围，没有父提交时使用 --root 与空树对比
+func commitDiffRange(commit, parent string) []string {
+	if parent == "" {
+		return []string{"--root", commit}
+	}
+	return []string{parent, commit}
+}
+
+// parseCommitDetail 解析 commitDetailFormat 格式的单条记录
+func parseCommitDetail(record string) (*models.GitCommitDetail, error) {
+	parts := strings.SplitN(record, "\x1f", commitDetailExtraFields+1)
+	if len(parts) <= commitDetailExtraFields {
+		return nil, fmt.Errorf("invalid commit detail format: %s", record)
+	}
+
+	entries, err := ParseLogRecords(parts[commitDetailExtraFields])
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) != 1 {
+		return nil, fmt.Errorf("invalid commit detail format: %s", record)
+	}
+
+	detail := &models.GitCommitDetail{
+		Commit:            entries[0],
+		AuthorTimezone:    lastField(parts[1]),
+		CommitterTimezone: lastField(parts[3]),
+		Signature: models.GitSignature{
+			Status: parts[4],
+			Signer: parts[5],
+			Key:    parts[6],
+		},
+		Files: []models.GitCommitFile{},
+	}
+	detail.AuthorTimestamp, _ = strconv.ParseInt(parts[0], 10, 64)
+	detail.CommitterTimestamp, _ = strconv.ParseInt(parts[2], 10, 64)
+
+	return detail, nil
+}
+
+// lastField 返回以空白分隔的最后一个字段，如 %ai 中的时区
+func lastField(value string) string {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[len(fields)-1]
+}
+
+// ParseRawNumstat 解析 diff-tree -z --raw --numstat 的输出
+// 先输出所有 raw 记录 ":<旧模式> <新模式> <旧对象> <新对象> <状态>\0<路径>\0[<新路径>\0]"，
+// 再按相同顺序输出 numstat 记录 "<新增>\t<删除>\t<路径>\0" 或重命名时的 "<新增>\t<删除>\t\0<旧路径>\0<新路径>\0"
+func ParseRawNumstat(output string) ([]models.GitCommitFile, error) {
+	files := []models.GitCommitFile{}
+	fields := strings.Split(output, "\x00")
+
+	i := 0
+	for ; i < len(fields) && strings.HasPrefix(fields[i], ":"); i++ {
+		meta := strings.Fields(fields[i])
+		if len(meta) < 5 || i+1 >= len(fields) {
+			return nil, fmt.Errorf("invalid raw diff record: %s", fields[i])
+		}
+
+		status := meta[4]
+		file := models.GitCommitFile{Status: status[:1]}
+		file.Similarity, _ = strconv.Atoi(status[1:])
+		i++
+		file.Filename = fields[i]
+		if file.Status == "R" || file.Status == "C" {
+			if i+1 >= len(fields) {
+				return nil, fmt.Errorf("invalid raw diff record: %s", fields[i])
+			}
+			i++
+			file.OldPath, file.Filename = file.Filename, fields[i]
+		}
+		files = append(files, file)
+	}
+
+	for n := range files {
+		if i >= len(fields) {
+			return nil, fmt.Errorf("missing numstat for %s", files[n].Filename)
+		}
+		stat := strings.SplitN(fields[i], "\t", 3)
+		if len(stat) != 3 {
+			return nil, fmt.Errorf("invalid numstat record: %s", fields[i])
+		}
+		if stat[0] == "-" && stat[1] == "-" {
+			files[n].Binary = true
+		} else {
+			files[n].Additions, _ = strconv.Atoi(stat[0])
+			files[n].Deletions, _ = strconv.Atoi(stat[1])
+		}
+		// 重命名/复制时路径为空，后面跟随旧路径与新路径两个字段
+		if stat[2] == "" {
+			i += 2
+		}
+		i++
+	}
+
+	return files, nil
+}
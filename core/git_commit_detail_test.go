@@ -0,0 +1,83 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-git-client-window/models"
+)
+
+func TestGetCommitDetail(t *testing.T) {
+	dir := newTestRepo(t)
+	writeTestFile(t, dir, "notes.txt", "one\ntwo\nthree\nfour\nfive\n")
+	writeTestFile(t, dir, "data.bin", "\x00\x01")
+	mustGit(t, dir, "add", ".")
+	mustGit(t, dir, "commit", "-m", "add files")
+
+	mustGit(t, dir, "mv", "notes.txt", "renamed.txt")
+	writeTestFile(t, dir, "renamed.txt", "one\ntwo\nthree\nfour\nfive\nsix\n")
+	writeTestFile(t, dir, "README.md", "changed\n")
+	mustGit(t, dir, "rm", "-q", "data.bin")
+	mustGit(t, dir, "add", ".")
+	mustGit(t, dir, "commit", "-m", "rework\n\nbody text")
+
+	detail, err := gitService.GetCommitDetail(dir, "HEAD", "")
+	require.NoError(t, err)
+	assert.Equal(t, "rework", detail.Commit.Subject)
+	assert.Equal(t, "body text", detail.Commit.Body)
+	assert.Equal(t, "N", detail.Signature.Status)
+	assert.NotZero(t, detail.AuthorTimestamp)
+	assert.Regexp(t, `^[+-]\d{4}$`, detail.AuthorTimezone)
+	assert.Equal(t, detail.Commit.Parents[0], detail.ComparedParent)
+
+	files := map[string]models.GitCommitFile{}
+	for _, file := range detail.Files {
+		files[file.Filename] = file
+	}
+	require.Len(t, files, 3)
+	assert.Equal(t, models.GitCommitFile{Filename: "README.md", Status: "M", Additions: 1, Deletions: 1}, files["README.md"])
+	assert.Equal(t, models.GitCommitFile{Filename: "data.bin", Status: "D", Binary: true}, files["data.bin"])
+	renamed := files["renamed.txt"]
+	assert.Equal(t, "R", renamed.Status)
+	assert.Equal(t, "notes.txt", renamed.OldPath)
+	assert.Equal(t, 1, renamed.Additions)
+	assert.Greater(t, renamed.Similarity, 50)
+	assert.Equal(t, 2, detail.Additions)
+	assert.Equal(t, 1, detail.Deletions)
+
+	diffs, err := gitService.GetCommitFileDiff(dir, "HEAD", "", "renamed.txt", "notes.txt")
+	require.NoError(t, err)
+	require.Len(t, diffs, 1)
+	assert.Equal(t, "R", diffs[0].Status)
+	assert.Equal(t, 1, diffs[0].Additions)
+
+	// 与指定父提交（此处为根提交）对比
+	root := mustGit(t, dir, "rev-list", "--max-parents=0", "HEAD")
+	detail, err = gitService.GetCommitDetail(dir, "HEAD", root[:len(root)-1])
+	require.NoError(t, err)
+	assert.Equal(t, []models.GitCommitFile{
+		{Filename: "README.md", Status: "M", Additions: 1, Deletions: 1},
+		{Filename: "renamed.txt", Status: "A", Additions: 6},
+	}, detail.Files)
+
+	// 根提交与空树对比
+	detail, err = gitService.GetCommitDetail(dir, "HEAD~1~1", "")
+	require.NoError(t, err)
+	assert.Empty(t, detail.ComparedParent)
+	require.Len(t, detail.Files, 1)
+	assert.Equal(t, "A", detail.Files[0].Status)
+
+	diffs, err = gitService.GetCommitFileDiff(dir, "HEAD~2", "", "README.md", "")
+	require.NoError(t, err)
+	require.Len(t, diffs, 1)
+	assert.Equal(t, "A", diffs[0].Status)
+
+	_, err = gitService.GetCommitDetail(dir, "no-such-commit", "")
+	assert.True(t, IsGitErrorKind(err, ErrKindUnknownRevision))
+	_, err = gitService.GetCommitDetail(dir, "--output=x", "")
+	assert.ErrorContains(t, err, "invalid revision")
+	_, err = gitService.GetCommitFileDiff(dir, "HEAD", "--output=x", "README.md", "")
+	assert.ErrorContains(t, err, "invalid revision")
+}
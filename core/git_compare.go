@@ -0,0 +1,123 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go-git-client-window/models"
+)
+
+// CompareCommitLimit 比较结果中每一侧最多返回的提交数，完整数量见 Ahead/Behind
+const CompareCommitLimit = 500
+
+// Compare 比较两个引用：共同祖先、领先/落后提交以及两点与三点语义下的改动文件
+func (s *GitCoreService) Compare(repoPath, base, head string) (*models.GitCompareResult, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(base) == "" || strings.TrimSpace(head) == "" {
+		return nil, fmt.Errorf("base and head cannot be empty")
+	}
+	if err := checkRevisionArgs([]string{base, head}); err != nil {
+		return nil, err
+	}
+
+	compare := &models.GitCompareResult{Base: base, Head: head, Files: []models.GitCommitFile{}}
+
+	result, err := runGit(repoPath, "rev-parse", "--verify", base+"^{commit}")
+	if err != nil {
+		return nil, err
+	}
+	compare.BaseHash = strings.TrimSpace(result.Stdout)
+
+	result, err = runGit(repoPath, "rev-parse", "--verify", head+"^{commit}")
+	if err != nil {
+		return nil, err
+	}
+	compare.HeadHash = strings.TrimSpace(result.Stdout)
+
+	// 无共同祖先时 merge-base 以退出码 1 结束，其余失败需要返回
+	result, err = runGit(repoPath, "merge-base", compare.BaseHash, compare.HeadHash)
+	switch {
+	case err == nil:
+		compare.MergeBase = strings.TrimSpace(result.Stdout)
+	case result == nil || result.ExitCode != 1:
+		return nil, err
+	}
+
+	// 左侧为 base 独有，右侧为 head 独有
+	result, err = runGit(repoPath, "rev-list", "--left-right", "--count", compare.BaseHash+"..."+compare.HeadHash)
+	if err != nil {
+		return nil, err
+	}
+	if counts := strings.Fields(result.Stdout); len(counts) == 2 {
+		compare.Behind, _ = strconv.Atoi(counts[0])
+		compare.Ahead, _ = strconv.Atoi(counts[1])
+	}
+
+	if compare.AheadCommits, err = compareCommits(repoPath, compare.BaseHash, compare.HeadHash); err != nil {
+		return nil, err
+	}
+	if compare.BehindCommits, err = compareCommits(repoPath, compare.HeadHash, compare.BaseHash); err != nil {
+		return nil, err
+	}
+
+	if compare.MergeBase != "" {
+		if compare.Files, err = compareFiles(repoPath, compare.MergeBase, compare.HeadHash); err != nil {
+			return nil, err
+		}
+	}
+	if compare.TwoDotFiles, err = compareFiles(repoPath, compare.BaseHash, compare.HeadHash); err != nil {
+		return nil, err
+	}
+	for _, file := range compare.Files {
+		compare.Additions += file.Additions
+		compare.Deletions += file.Deletions
+	}
+
+	return compare, nil
+}
+
+// CompareDiff 获取两个引用之间的结构化差异
+// threeDot 为 true 时对比 merge-base 与 head（base...head），否则直接对比 base 与 head；filename 为空时返回全部文件
+func (s *GitCoreService) CompareDiff(repoPath, base, head, filename string, threeDot bool) ([]models.FileDiff, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(base) == "" || strings.TrimSpace(head) == "" {
+		return nil, fmt.Errorf("base and head cannot be empty")
+	}
+	if err := checkRevisionArgs([]string{base, head}); err != nil {
+		return nil, err
+	}
+
+	args := []string{base, head}
+	if threeDot {
+		args = []string{base + "..." + head}
+	}
+	args = append(args, "--")
+	if filename != "" {
+		args = append(args, filename)
+	}
+
+	return runDiff(repoPath, []string{"diff"}, args...)
+}
+
+// compareCommits 返回 to 中不属于 from 的提交
+func compareCommits(repoPath, from, to string) ([]models.GitLogEntry, error) {
+	result, err := runGit(repoPath, "log", "-z", "--format="+logFormat, "-n", strconv.Itoa(CompareCommitLimit), from+".."+to, "--")
+	if err != nil {
+		return nil, err
+	}
+	return ParseLogRecords(result.Stdout)
+}
+
+// compareFiles 返回两个提交之间改动的文件及行数统计
+func compareFiles(repoPath, from, to string) ([]models.GitCommitFile, error) {
+	result, err := runGit(repoPath, "diff-tree", "-r", "-z", "--raw", "--numstat", "--find-renames", from, to)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRawNumstat(result.Stdout)
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	dir := newConflictRepo(t)
+
+	compare, err := gitService.Compare(dir, "master", "feature")
+	require.NoError(t, err)
+	assert.Equal(t, mustGit(t, dir, "rev-parse", "master~1"), compare.MergeBase+"\n")
+	assert.Equal(t, 2, compare.Ahead)
+	assert.Equal(t, 1, compare.Behind)
+	require.Len(t, compare.AheadCommits, 2)
+	assert.Equal(t, "feature file", compare.AheadCommits[0].Subject)
+	require.Len(t, compare.BehindCommits, 1)
+	assert.Equal(t, "master readme", compare.BehindCommits[0].Subject)
+
+	// 三点：feature 相对共同祖先的改动
+	require.Len(t, compare.Files, 2)
+	assert.Equal(t, 2, compare.Additions)
+	assert.Equal(t, 1, compare.Deletions)
+	// 两点：README 在两侧都被修改，仍然出现在差异中
+	require.Len(t, compare.TwoDotFiles, 2)
+
+	diffs, err := gitService.CompareDiff(dir, "master", "feature", "README.md", true)
+	require.NoError(t, err)
+	require.Len(t, diffs, 1)
+	assert.Equal(t, "hello", diffs[0].Hunks[0].Lines[0].Content)
+
+	diffs, err = gitService.CompareDiff(dir, "master", "feature", "README.md", false)
+	require.NoError(t, err)
+	require.Len(t, diffs, 1)
+	assert.Equal(t, "master", diffs[0].Hunks[0].Lines[0].Content)
+
+	diffs, err = gitService.CompareDiff(dir, "master", "feature", "", true)
+	require.NoError(t, err)
+	assert.Len(t, diffs, 2)
+
+	// 无共同祖先
+	mustGit(t, dir, "checkout", "--orphan", "orphan")
+	mustGit(t, dir, "commit", "-m", "orphan")
+	compare, err = gitService.Compare(dir, "master", "orphan")
+	require.NoError(t, err)
+	assert.Empty(t, compare.MergeBase)
+	assert.Empty(t, compare.Files)
+	assert.Equal(t, 1, compare.Ahead)
+
+	_, err = gitService.Compare(dir, "master", "no-such-branch")
+	assert.True(t, IsGitErrorKind(err, ErrKindUnknownRevision))
+	_, err = gitService.Compare(dir, "--all", "master")
+	assert.ErrorContains(t, err, "invalid revision")
+	_, err = gitService.CompareDiff(dir, "master", "--output=x", "", false)
+	assert.ErrorContains(t, err, "invalid revision")
+}
@@ -0,0 +1,287 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go-git-client-window/models"
+)
+
+// 冲突标记（默认长度为 7）
+const (
+	conflictMarkerOurs   = "<<<<<<<"
+	conflictMarkerBase   = "|||||||"
+	conflictMarkerSplit  = "======="
+	conflictMarkerTheirs = ">>>>>>>"
+)
+
+// GetConflictFile 获取冲突文件的 base/ours/theirs 内容以及工作区中的冲突区域
+func (s *GitCoreService) GetConflictFile(repoPath, filename string) (*models.GitConflictFile, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(filename) == "" {
+		return nil, fmt.Errorf("filename cannot be empty")
+	}
+
+	result, err := runGit(repoPath, "ls-files", "-u", "-z", "--", filename)
+	if err != nil {
+		return nil, err
+	}
+	stages := parseUnmergedStages(result.Stdout)
+	if len(stages) == 0 {
+		return nil, fmt.Errorf("file is not in conflict: %s", filename)
+	}
+
+	file := &models.GitConflictFile{Filename: filename}
+	blobs := map[string]*string{"1": &file.Base, "2": &file.Ours, "3": &file.Theirs}
+	for stage, target := range blobs {
+		if !stages[stage] {
+			continue
+		}
+		result, err := runGit(repoPath, "show", ":"+stage+":"+filename)
+		if err != nil {
+			return nil, err
+		}
+		if isBinaryContent(result.Stdout) {
+			file.Binary = true
+		}
+		*target = result.Stdout
+	}
+	file.HasBase, file.HasOurs, file.HasTheirs = stages["1"], stages["2"], stages["3"]
+
+	data, err := os.ReadFile(filepath.Join(repoPath, filename))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if isBinaryContent(string(data)) {
+		file.Binary = true
+	}
+
+	if file.Binary {
+		file.Base, file.Ours, file.Theirs = "", "", ""
+		file.Regions = []models.GitConflictRegion{}
+		return file, nil
+	}
+
+	file.Content = string(data)
+	if file.Regions, err = ParseConflictRegions(file.Content); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// ResolveConflictContent 按区域选择或手工编辑的内容写入冲突文件，并标记为已解决
+func (s *GitCoreService) ResolveConflictContent(repoPath string, resolution models.GitConflictResolution) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(resolution.Filename) == "" {
+		return "", fmt.Errorf("filename cannot be empty")
+	}
+
+	path := filepath.Join(repoPath, resolution.Filename)
+	var content string
+	switch {
+	case resolution.Content != nil && len(resolution.Choices) > 0:
+		return "", fmt.Errorf("content and choices cannot both be set")
+	case resolution.Content != nil:
+		// 允许手工解决为空文件
+		content = *resolution.Content
+	case len(resolution.Choices) > 0:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		if content, err = ApplyConflictChoices(string(data), resolution.Choices); err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("content or choices must be set")
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return "", err
+	}
+
+	return ExecuteGitCommand(repoPath, "add", "--", resolution.Filename)
+}
+
+// parseUnmergedStages 解析 ls-files -u -z 输出，返回存在的 stage 编号
+// 每条记录格式为 "<mode> <object> <stage>\t<path>"
+func parseUnmergedStages(output string) map[string]bool {
+	stages := map[string]bool{}
+	for _, record := range strings.Split(output, "\x00") {
+		info, _, ok := strings.Cut(record, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(info)
+		if len(fields) == 3 {
+			stages[fields[2]] = true
+		}
+	}
+	return stages
+}
+
+// isBinaryContent 与 git 相同，按前 8000 字节中是否含 NUL 判断二进制
+func isBinaryContent(content string) bool {
+	if len(content) > 8000 {
+		content = content[:8000]
+	}
+	return strings.IndexByte(content, 0) >= 0
+}
+
+// conflictMarker 判断行是否为指定冲突标记，返回标记后的标签
+func conflictMarker(line, marker string) (string, bool) {
+	line = strings.TrimRight(line, "\r\n")
+	if !strings.HasPrefix(line, marker) {
+		return "", false
+	}
+	rest := line[len(marker):]
+	if rest == "" {
+		return "", true
+	}
+	if rest[0] != ' ' {
+		return "", false
+	}
+	return rest[1:], true
+}
+
+// ParseConflictRegions 解析文件中的冲突区域，支持 merge、diff3 与 zdiff3 风格
+func ParseConflictRegions(content string) ([]models.GitConflictRegion, error) {
+	regions := []models.GitConflictRegion{}
+	err := walkConflicts(content, nil, func(region models.GitConflictRegion) {
+		regions = append(regions, region)
+	})
+	return regions, err
+}
+
+// ApplyConflictChoices 按区域选择生成解决后的文件内容，所有区域都必须给出选择，且选择不能指向不存在的区域
+func ApplyConflictChoices(content string, choices []models.GitConflictChoice) (string, error) {
+	byRegion := make(map[int]models.GitConflictChoice, len(choices))
+	for _, choice := range choices {
+		byRegion[choice.Region] = choice
+	}
+
+	var out bytes.Buffer
+	var resolveErr error
+	err := walkConflicts(content, &out, func(region models.GitConflictRegion) {
+		if resolveErr != nil {
+			return
+		}
+		choice, ok := byRegion[region.Index]
+		delete(byRegion, region.Index)
+		if !ok {
+			resolveErr = fmt.Errorf("conflict region %d is not resolved", region.Index)
+			return
+		}
+		switch choice.Choice {
+		case models.ConflictChoiceOurs:
+			out.WriteString(region.Ours)
+		case models.ConflictChoiceTheirs:
+			out.WriteString(region.Theirs)
+		case models.ConflictChoiceBase:
+			if !region.HasBase {
+				resolveErr = fmt.Errorf("conflict region %d has no base section", region.Index)
+				return
+			}
+			out.WriteString(region.Base)
+		case models.ConflictChoiceOursTheirs:
+			out.WriteString(region.Ours)
+			out.WriteString(region.Theirs)
+		case models.ConflictChoiceTheirsOurs:
+			out.WriteString(region.Theirs)
+			out.WriteString(region.Ours)
+		case models.ConflictChoiceCustom:
+			out.WriteString(choice.Content)
+		default:
+			resolveErr = fmt.Errorf("unsupported conflict choice: %s", choice.Choice)
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+	for index := range byRegion {
+		// 如文件中已没有冲突标记
+		return "", fmt.Errorf("conflict region %d does not exist", index)
+	}
+
+	return out.String(), nil
+}
+
+// walkConflicts 逐行扫描内容，冲突区域外的行写入 out（可为 nil），每个完整区域回调 onRegion
+func walkConflicts(content string, out *bytes.Buffer, onRegion func(models.GitConflictRegion)) error {
+	const (
+		sectionNone = iota
+		sectionOurs
+		sectionBase
+		sectionTheirs
+	)
+
+	var region models.GitConflictRegion
+	var ours, base, theirs strings.Builder
+	section := sectionNone
+
+	for i, line := range strings.SplitAfter(content, "\n") {
+		if line == "" {
+			continue
+		}
+		lineNo := i + 1
+
+		switch section {
+		case sectionNone:
+			if label, ok := conflictMarker(line, conflictMarkerOurs); ok {
+				region = models.GitConflictRegion{Index: region.Index, StartLine: lineNo, OursLabel: label}
+				ours.Reset()
+				base.Reset()
+				theirs.Reset()
+				section = sectionOurs
+				continue
+			}
+			if out != nil {
+				out.WriteString(line)
+			}
+		case sectionOurs, sectionBase:
+			if label, ok := conflictMarker(line, conflictMarkerBase); ok && section == sectionOurs {
+				region.HasBase = true
+				region.BaseLabel = label
+				section = sectionBase
+				continue
+			}
+			if _, ok := conflictMarker(line, conflictMarkerSplit); ok {
+				section = sectionTheirs
+				continue
+			}
+			if section == sectionOurs {
+				ours.WriteString(line)
+			} else {
+				base.WriteString(line)
+			}
+		case sectionTheirs:
+			if label, ok := conflictMarker(line, conflictMarkerTheirs); ok {
+				region.EndLine = lineNo
+				region.TheirsLabel = label
+				region.Ours, region.Base, region.Theirs = ours.String(), base.String(), theirs.String()
+				onRegion(region)
+				region.Index++
+				section = sectionNone
+				continue
+			}
+			theirs.WriteString(line)
+		}
+	}
+
+	if section != sectionNone {
+		return fmt.Errorf("unterminated conflict region starting at line %d", region.StartLine)
+	}
+	return nil
+}
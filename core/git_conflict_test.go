@@ -0,0 +1,138 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-git-client-window/models"
+)
+
+func TestParseConflictRegions(t *testing.T) {
+	content := "top\n" +
+		"<<<<<<< HEAD\n" +
+		"ours\n" +
+		"||||||| base\n" +
+		"base\n" +
+		"=======\n" +
+		"theirs\n" +
+		">>>>>>> feature\n" +
+		"middle\n" +
+		"<<<<<<< HEAD\r\n" +
+		"a\r\n" +
+		"=======\r\n" +
+		">>>>>>> feature\r\n" +
+		"bottom\n"
+
+	regions, err := ParseConflictRegions(content)
+	require.NoError(t, err)
+	require.Len(t, regions, 2)
+
+	assert.Equal(t, models.GitConflictRegion{
+		Index: 0, StartLine: 2, EndLine: 8,
+		Ours: "ours\n", Base: "base\n", Theirs: "theirs\n", HasBase: true,
+		OursLabel: "HEAD", BaseLabel: "base", TheirsLabel: "feature",
+	}, regions[0])
+	assert.Equal(t, 1, regions[1].Index)
+	assert.Equal(t, "a\r\n", regions[1].Ours)
+	assert.Empty(t, regions[1].Theirs)
+	assert.False(t, regions[1].HasBase)
+
+	resolved, err := ApplyConflictChoices(content, []models.GitConflictChoice{
+		{Region: 0, Choice: models.ConflictChoiceTheirsOurs},
+		{Region: 1, Choice: models.ConflictChoiceCustom, Content: "custom\n"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "top\ntheirs\nours\nmiddle\ncustom\nbottom\n", resolved)
+
+	_, err = ApplyConflictChoices(content, []models.GitConflictChoice{{Region: 0, Choice: models.ConflictChoiceOurs}})
+	assert.Error(t, err)
+	_, err = ApplyConflictChoices(content, []models.GitConflictChoice{
+		{Region: 0, Choice: models.ConflictChoiceOurs},
+		{Region: 1, Choice: models.ConflictChoiceBase},
+	})
+	assert.Error(t, err)
+
+	_, err = ParseConflictRegions("<<<<<<< HEAD\nours\n")
+	assert.Error(t, err)
+
+	// 标记后必须是空格或行尾
+	regions, err = ParseConflictRegions("<<<<<<<<< not a marker\n")
+	require.NoError(t, err)
+	assert.Empty(t, regions)
+}
+
+func TestConflictFile(t *testing.T) {
+	for _, style := range []string{"merge", "diff3", "zdiff3"} {
+		t.Run(style, func(t *testing.T) {
+			dir := newConflictRepo(t)
+			mustGit(t, dir, "config", "merge.conflictStyle", style)
+			_, err := gitService.MergeWithOptions(dir, models.GitMergeOptions{Branch: "feature"})
+			require.Error(t, err)
+
+			file, err := gitService.GetConflictFile(dir, "README.md")
+			require.NoError(t, err)
+			assert.Equal(t, "hello\n", file.Base)
+			assert.Equal(t, "master\n", file.Ours)
+			assert.Equal(t, "feature\n", file.Theirs)
+			assert.True(t, file.HasBase && file.HasOurs && file.HasTheirs)
+			require.Len(t, file.Regions, 1)
+			assert.Equal(t, "master\n", file.Regions[0].Ours)
+			assert.Equal(t, "feature\n", file.Regions[0].Theirs)
+			assert.Equal(t, style != "merge", file.Regions[0].HasBase)
+
+			_, err = gitService.ResolveConflictContent(dir, models.GitConflictResolution{
+				Filename: "README.md",
+				Choices:  []models.GitConflictChoice{{Region: 0, Choice: models.ConflictChoiceTheirs}},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, "feature\n", mustGit(t, dir, "show", ":README.md"))
+
+			conflicts, err := gitService.GetMergeConflicts(dir)
+			require.NoError(t, err)
+			assert.Empty(t, conflicts)
+		})
+	}
+
+	t.Run("手工编辑内容", func(t *testing.T) {
+		dir := newConflictRepo(t)
+		_, err := gitService.MergeWithOptions(dir, models.GitMergeOptions{Branch: "feature"})
+		require.Error(t, err)
+
+		edited := "edited\n"
+		_, err = gitService.ResolveConflictContent(dir, models.GitConflictResolution{
+			Filename: "README.md",
+			Content:  &edited,
+			Choices:  []models.GitConflictChoice{{Region: 0, Choice: models.ConflictChoiceOurs}},
+		})
+		assert.Error(t, err)
+		_, err = gitService.ResolveConflictContent(dir, models.GitConflictResolution{Filename: "README.md"})
+		assert.Error(t, err)
+
+		_, err = gitService.ResolveConflictContent(dir, models.GitConflictResolution{Filename: "README.md", Content: &edited})
+		require.NoError(t, err)
+		assert.Equal(t, "edited\n", mustGit(t, dir, "show", ":README.md"))
+
+		_, err = gitService.GetConflictFile(dir, "README.md")
+		assert.Error(t, err)
+
+		// 已没有冲突标记时区域选择不会静默写回原内容
+		_, err = gitService.ResolveConflictContent(dir, models.GitConflictResolution{
+			Filename: "README.md",
+			Choices:  []models.GitConflictChoice{{Region: 0, Choice: models.ConflictChoiceOurs}},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("解决为空文件", func(t *testing.T) {
+		dir := newConflictRepo(t)
+		_, err := gitService.MergeWithOptions(dir, models.GitMergeOptions{Branch: "feature"})
+		require.Error(t, err)
+
+		empty := ""
+		_, err = gitService.ResolveConflictContent(dir, models.GitConflictResolution{Filename: "README.md", Content: &empty})
+		require.NoError(t, err)
+		assert.Empty(t, mustGit(t, dir, "show", ":README.md"))
+	})
+}
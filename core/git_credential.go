@@ -0,0 +1,431 @@
+package core
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-git-client-window/models"
+)
+
+// 凭据桥接：应用内监听本机端口，git 通过 credential helper 和 GIT_ASKPASS/SSH_ASKPASS 重新启动本程序，
+// 本程序以客户端模式连回应用，由界面提示用户输入，避免 git 在不存在的终端上等待输入
+
+// 传给 git 子进程的桥接环境变量
+const (
+	credentialAddrEnv  = "GIT_CLIENT_CREDENTIAL_ADDR"
+	credentialTokenEnv = "GIT_CLIENT_CREDENTIAL_TOKEN"
+)
+
+// 桥接请求的动作，get/store/erase 与 git credential helper 协议一致
+const (
+	credentialActionGet     = "get"
+	credentialActionStore   = "store"
+	credentialActionErase   = "erase"
+	credentialActionAskpass = "askpass"
+)
+
+// CredentialPromptTimeout 等待用户输入凭据的最长时间
+const CredentialPromptTimeout = 5 * time.Minute
+
+// CredentialPromptFunc 向用户请求凭据，git 进程退出或等待超时后 ctx 被取消
+type CredentialPromptFunc func(ctx context.Context, req models.GitCredentialRequest) (*models.GitCredentialResponse, error)
+
+// credentialMessage 客户端发给应用的请求，一行 JSON
+type credentialMessage struct {
+	Token      string               `json:"token"`
+	Action     string               `json:"action"`
+	Prompt     string               `json:"prompt"`
+	Credential models.GitCredential `json:"credential"`
+}
+
+// credentialReply 应用返回给客户端的回复，一行 JSON
+type credentialReply struct {
+	Credential models.GitCredential `json:"credential"`
+	Answer     string               `json:"answer"`
+	Canceled   bool                 `json:"canceled"`
+	Error      string               `json:"error"`
+}
+
+// activeCredentialBridge 当前生效的凭据桥接，RunGitCommand 为每个 git 进程注入其环境变量
+var activeCredentialBridge atomic.Pointer[CredentialBridge]
+
+// SetCredentialBridge 设置全局凭据桥接，传 nil 恢复 git 默认的凭据处理
+func SetCredentialBridge(bridge *CredentialBridge) {
+	activeCredentialBridge.Store(bridge)
+}
+
+// credentialEnv 返回当前凭据桥接的环境变量，未设置时为空
+func credentialEnv() []string {
+	if bridge := activeCredentialBridge.Load(); bridge != nil {
+		return bridge.Env()
+	}
+	return nil
+}
+
+// CredentialBridge 应用侧的凭据桥接服务
+type CredentialBridge struct {
+	executable string
+	token      string
+	listener   net.Listener
+	prompt     CredentialPromptFunc
+	store      *CredentialStore // 为 nil 时不保存凭据
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	nextID atomic.Uint64
+
+	mu      sync.Mutex
+	pending map[string]models.GitCredential // 用户选择记住、等待 git 确认认证成功的凭据
+}
+
+// NewCredentialBridge 在本机回环地址上启动凭据桥接，executable 为 git 回调时启动的本程序路径
+func NewCredentialBridge(executable string, prompt CredentialPromptFunc, store *CredentialStore) (*CredentialBridge, error) {
+	if strings.TrimSpace(executable) == "" {
+		return nil, fmt.Errorf("executable cannot be empty")
+	}
+	if prompt == nil {
+		return nil, fmt.Errorf("prompt cannot be nil")
+	}
+
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	bridge := &CredentialBridge{
+		executable: executable,
+		token:      hex.EncodeToString(token),
+		listener:   listener,
+		prompt:     prompt,
+		store:      store,
+		ctx:        ctx,
+		cancel:     cancel,
+		pending:    map[string]models.GitCredential{},
+	}
+	go bridge.serve()
+	return bridge, nil
+}
+
+// Close 停止桥接服务并取消所有等待中的提示
+func (b *CredentialBridge) Close() error {
+	b.cancel()
+	return b.listener.Close()
+}
+
+// Env 返回让 git 通过桥接请求凭据的环境变量
+func (b *CredentialBridge) Env() []string {
+	// 通过 GIT_CONFIG_COUNT 追加 credential.helper，不覆盖用户已配置的 helper，也不占用已有的序号
+	index, _ := strconv.Atoi(os.Getenv("GIT_CONFIG_COUNT"))
+	helper := "!" + shellQuote(filepath.ToSlash(b.executable)) + " credential"
+
+	return []string{
+		credentialAddrEnv + "=" + b.listener.Addr().String(),
+		credentialTokenEnv + "=" + b.token,
+		"GIT_ASKPASS=" + b.executable,
+		"SSH_ASKPASS=" + b.executable,
+		"SSH_ASKPASS_REQUIRE=force",
+		"GIT_CONFIG_COUNT=" + strconv.Itoa(index+1),
+		fmt.Sprintf("GIT_CONFIG_KEY_%d=credential.helper", index),
+		fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", index, helper),
+	}
+}
+
+// serve 接受客户端连接，直到监听被关闭
+func (b *CredentialBridge) serve() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+		go b.handle(conn)
+	}
+}
+
+// handle 处理一个客户端连接，每个连接只有一次请求和回复
+func (b *CredentialBridge) handle(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		return
+	}
+	var msg credentialMessage
+	if err := json.Unmarshal(line, &msg); err != nil {
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(msg.Token), []byte(b.token)) != 1 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(b.ctx, CredentialPromptTimeout)
+	defer cancel()
+	// 客户端不再发送数据，连接断开说明 git 进程已退出（如操作被取消）
+	go func() {
+		reader.ReadByte()
+		cancel()
+	}()
+
+	reply, err := b.dispatch(ctx, msg)
+	if err != nil {
+		reply = &credentialReply{Error: err.Error()}
+	}
+	data, err := json.Marshal(reply)
+	if err != nil {
+		return
+	}
+	conn.Write(append(data, '\n'))
+}
+
+// dispatch 按动作处理请求
+func (b *CredentialBridge) dispatch(ctx context.Context, msg credentialMessage) (*credentialReply, error) {
+	switch msg.Action {
+	case credentialActionGet:
+		return b.fill(ctx, msg.Credential)
+	case credentialActionStore:
+		return &credentialReply{}, b.approve(msg.Credential)
+	case credentialActionErase:
+		return &credentialReply{}, b.reject(msg.Credential)
+	case credentialActionAskpass:
+		return b.askpass(ctx, msg.Prompt)
+	default:
+		return nil, fmt.Errorf("unsupported credential action: %s", msg.Action)
+	}
+}
+
+// fill 优先使用已保存的凭据，否则提示用户输入
+func (b *CredentialBridge) fill(ctx context.Context, cred models.GitCredential) (*credentialReply, error) {
+	if b.store != nil {
+		// 读取失败时（如存储文件损坏）退回到提示用户
+		if stored, err := b.store.Get(cred); err == nil && stored != nil {
+			return &credentialReply{Credential: *stored}, nil
+		}
+	}
+
+	resp, err := b.prompt(ctx, models.GitCredentialRequest{
+		ID:          b.newRequestID(),
+		Kind:        models.CredentialKindCredential,
+		Secret:      true,
+		Protocol:    cred.Protocol,
+		Host:        cred.Host,
+		Path:        cred.Path,
+		Username:    cred.Username,
+		CanRemember: b.store != nil,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Canceled {
+		return &credentialReply{Canceled: true}, nil
+	}
+
+	if resp.Username != "" {
+		cred.Username = resp.Username
+	}
+	cred.Password = resp.Password
+	if resp.Remember && b.store != nil {
+		b.mu.Lock()
+		b.pending[credentialKey(cred)] = cred
+		b.mu.Unlock()
+	}
+	return &credentialReply{Credential: cred}, nil
+}
+
+// approve git 认证成功后调用，保存用户选择记住的凭据
+func (b *CredentialBridge) approve(cred models.GitCredential) error {
+	key := credentialKey(cred)
+	b.mu.Lock()
+	remembered, ok := b.pending[key]
+	delete(b.pending, key)
+	b.mu.Unlock()
+
+	if !ok || remembered.Password != cred.Password {
+		return nil
+	}
+	return b.store.Save(cred)
+}
+
+// reject git 认证失败后调用，删除已保存的凭据
+func (b *CredentialBridge) reject(cred models.GitCredential) error {
+	b.mu.Lock()
+	delete(b.pending, credentialKey(cred))
+	b.mu.Unlock()
+
+	if b.store == nil {
+		return nil
+	}
+	return b.store.Erase(cred)
+}
+
+// askpass 转发 GIT_ASKPASS/SSH_ASKPASS 的提示
+func (b *CredentialBridge) askpass(ctx context.Context, prompt string) (*credentialReply, error) {
+	lower := strings.ToLower(prompt)
+	resp, err := b.prompt(ctx, models.GitCredentialRequest{
+		ID:     b.newRequestID(),
+		Kind:   models.CredentialKindAskpass,
+		Prompt: prompt,
+		// 用户名与 SSH 主机指纹确认（yes/no）无需隐藏，其余为密码或私钥密码
+		Secret: !strings.Contains(lower, "username") && !strings.Contains(lower, "yes/no"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &credentialReply{Answer: resp.Answer, Canceled: resp.Canceled}, nil
+}
+
+// newRequestID 生成凭据请求 ID
+func (b *CredentialBridge) newRequestID() string {
+	return "credential-" + strconv.FormatUint(b.nextID.Add(1), 10)
+}
+
+// credentialKey 凭据的唯一标识
+func credentialKey(cred models.GitCredential) string {
+	return cred.Protocol + "://" + cred.Username + "@" + cred.Host + "/" + cred.Path
+}
+
+// IsCredentialClient 判断本进程是否由 git 作为 credential helper 或 askpass 程序启动
+func IsCredentialClient() bool {
+	return os.Getenv(credentialAddrEnv) != ""
+}
+
+// RunCredentialClient 以客户端模式运行并返回进程退出码
+// args 为 "credential <action>" 时按 credential helper 协议处理，否则 args[0] 为 askpass 的提示语
+func RunCredentialClient(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	msg := credentialMessage{Token: os.Getenv(credentialTokenEnv), Action: credentialActionAskpass}
+	helper := len(args) > 0 && args[0] == "credential"
+	if helper {
+		if len(args) < 2 {
+			fmt.Fprintln(stderr, "usage: credential <get|store|erase>")
+			return 1
+		}
+		msg.Action = args[1]
+		switch msg.Action {
+		case credentialActionGet, credentialActionStore, credentialActionErase:
+		default:
+			// 协议要求忽略不认识的动作
+			return 0
+		}
+		cred, err := ParseCredential(stdin)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		msg.Credential = cred
+	} else if len(args) > 0 {
+		msg.Prompt = args[0]
+	}
+
+	reply, err := callCredentialBridge(os.Getenv(credentialAddrEnv), msg)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		if msg.Action == credentialActionGet {
+			// 提示超时或桥接出错时同样让 git 结束，否则 git 会退回到 GIT_ASKPASS（即本程序）再次提示
+			fmt.Fprintln(stdout, "quit=1")
+			return 0
+		}
+		return 1
+	}
+
+	switch {
+	case !helper:
+		if reply.Canceled {
+			return 1
+		}
+		fmt.Fprintln(stdout, reply.Answer)
+	case msg.Action != credentialActionGet:
+	case reply.Canceled:
+		// 让 git 不再尝试其他方式，直接以取消结束
+		fmt.Fprintln(stdout, "quit=1")
+	default:
+		FormatCredential(stdout, reply.Credential)
+	}
+	return 0
+}
+
+// callCredentialBridge 连接应用并发送请求
+func callCredentialBridge(addr string, msg credentialMessage) (*credentialReply, error) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("connect credential bridge: %w", err)
+	}
+	defer conn.Close()
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.Write(append(data, '\n')); err != nil {
+		return nil, err
+	}
+
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		return nil, fmt.Errorf("read credential bridge reply: %w", err)
+	}
+	var reply credentialReply
+	if err := json.Unmarshal(line, &reply); err != nil {
+		return nil, err
+	}
+	if reply.Error != "" {
+		return nil, fmt.Errorf("%s", reply.Error)
+	}
+	return &reply, nil
+}
+
+// ParseCredential 解析 git credential 协议的 key=value 输入，遇到空行结束
+func ParseCredential(r io.Reader) (models.GitCredential, error) {
+	var cred models.GitCredential
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if line == "" {
+			break
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return cred, fmt.Errorf("invalid credential line: %s", line)
+		}
+		switch key {
+		case "protocol":
+			cred.Protocol = value
+		case "host":
+			cred.Host = value
+		case "path":
+			cred.Path = value
+		case "username":
+			cred.Username = value
+		case "password":
+			cred.Password = value
+		}
+	}
+	return cred, scanner.Err()
+}
+
+// FormatCredential 按 git credential 协议输出用户名和密码
+func FormatCredential(w io.Writer, cred models.GitCredential) {
+	if cred.Username != "" {
+		fmt.Fprintf(w, "username=%s\n", cred.Username)
+	}
+	if cred.Password != "" {
+		fmt.Fprintf(w, "password=%s\n", cred.Password)
+	}
+}
@@ -0,0 +1,53 @@
+//go:build darwin
+
+package core
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"strings"
+)
+
+// keychainItemNotFound security 命令找不到钥匙串项时的退出码（errSecItemNotFound）
+const keychainItemNotFound = 44
+
+// keychainKeyStore 通过 security 命令把密钥保存在 macOS 钥匙串中
+type keychainKeyStore struct {
+	account string
+}
+
+// NewSystemKeyStore 返回系统密钥存储，macOS 下保存在登录钥匙串中
+func NewSystemKeyStore(dir string) CredentialKeyStore {
+	return &keychainKeyStore{account: dir}
+}
+
+// LoadKey 实现 CredentialKeyStore
+func (s *keychainKeyStore) LoadKey() ([]byte, error) {
+	output, err := exec.Command("security", "find-generic-password",
+		"-s", credentialKeyService, "-a", s.account, "-w").Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == keychainItemNotFound {
+			return nil, fs.ErrNotExist
+		}
+		return nil, fmt.Errorf("read credential key from keychain: %w", err)
+	}
+	return hex.DecodeString(strings.TrimSpace(string(output)))
+}
+
+// SaveKey 实现 CredentialKeyStore
+func (s *keychainKeyStore) SaveKey(key []byte) error {
+	// -w 作为最后一个参数且不带值时 security 会提示输入（并要求再次确认），从 stdin 读取，
+	// 避免密钥出现在命令行参数中被其他进程看到
+	cmd := exec.Command("security", "add-generic-password", "-U",
+		"-s", credentialKeyService, "-a", s.account, "-w")
+	encoded := hex.EncodeToString(key)
+	cmd.Stdin = strings.NewReader(encoded + "\n" + encoded + "\n")
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("save credential key to keychain: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+//go:build !windows && !darwin
+
+package core
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"strings"
+)
+
+// secretServiceKeyStore 通过 secret-tool（libsecret）把密钥保存在 Secret Service（如 GNOME Keyring、KWallet）中
+type secretServiceKeyStore struct {
+	account string
+}
+
+// NewSystemKeyStore 返回系统密钥存储，Linux 等系统下使用 Secret Service，不可用时无法创建凭据存储
+func NewSystemKeyStore(dir string) CredentialKeyStore {
+	return &secretServiceKeyStore{account: dir}
+}
+
+// LoadKey 实现 CredentialKeyStore
+func (s *secretServiceKeyStore) LoadKey() ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("secret-tool", "lookup", "service", credentialKeyService, "account", s.account)
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		// 找不到时 secret-tool 以退出码 1 结束且没有错误信息
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 && strings.TrimSpace(stderr.String()) == "" {
+			return nil, fs.ErrNotExist
+		}
+		return nil, fmt.Errorf("read credential key from secret service: %w %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return hex.DecodeString(strings.TrimSpace(string(output)))
+}
+
+// SaveKey 实现 CredentialKeyStore，密钥通过 stdin 传入，不出现在命令行中
+func (s *secretServiceKeyStore) SaveKey(key []byte) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command("secret-tool", "store", "--label=Go Git Client credential key",
+		"service", credentialKeyService, "account", s.account)
+	cmd.Stdin = strings.NewReader(hex.EncodeToString(key))
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("save credential key to secret service: %w %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+//go:build windows
+
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+// dpapiKeyFile 经 DPAPI 加密后的密钥文件，只有同一 Windows 用户才能解密
+const dpapiKeyFile = "credentials.key.dpapi"
+
+// cryptProtectUIForbidden CRYPTPROTECT_UI_FORBIDDEN，禁止 DPAPI 弹出界面
+const cryptProtectUIForbidden = 0x1
+
+var (
+	crypt32                = syscall.NewLazyDLL("crypt32.dll")
+	kernel32               = syscall.NewLazyDLL("kernel32.dll")
+	procCryptProtectData   = crypt32.NewProc("CryptProtectData")
+	procCryptUnprotectData = crypt32.NewProc("CryptUnprotectData")
+	procLocalFree          = kernel32.NewProc("LocalFree")
+)
+
+// dataBlob DATA_BLOB 结构
+type dataBlob struct {
+	size uint32
+	data *byte
+}
+
+// dpapiKeyStore 使用 DPAPI 以当前用户身份加密保存密钥
+type dpapiKeyStore struct {
+	path string
+}
+
+// NewSystemKeyStore 返回系统密钥存储，Windows 下密钥经 DPAPI 加密后保存在 dir 中
+func NewSystemKeyStore(dir string) CredentialKeyStore {
+	return &dpapiKeyStore{path: filepath.Join(dir, dpapiKeyFile)}
+}
+
+// LoadKey 实现 CredentialKeyStore
+func (s *dpapiKeyStore) LoadKey() ([]byte, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	return dpapiCall(procCryptUnprotectData, data)
+}
+
+// SaveKey 实现 CredentialKeyStore
+func (s *dpapiKeyStore) SaveKey(key []byte) error {
+	data, err := dpapiCall(procCryptProtectData, key)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o600)
+}
+
+// dpapiCall 调用 CryptProtectData 或 CryptUnprotectData，两者参数布局相同
+func dpapiCall(proc *syscall.LazyProc, input []byte) ([]byte, error) {
+	in := dataBlob{size: uint32(len(input))}
+	if len(input) > 0 {
+		in.data = &input[0]
+	}
+	var out dataBlob
+
+	r, _, err := proc.Call(
+		uintptr(unsafe.Pointer(&in)), 0, 0, 0, 0,
+		cryptProtectUIForbidden,
+		uintptr(unsafe.Pointer(&out)),
+	)
+	if r == 0 {
+		return nil, err
+	}
+	defer procLocalFree.Call(uintptr(unsafe.Pointer(out.data)))
+
+	return append([]byte(nil), unsafe.Slice(out.data, out.size)...), nil
+}
@@ -0,0 +1,219 @@
+package core
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"go-git-client-window/models"
+)
+
+// credentialStoreFile 凭据存储目录中的加密数据文件
+const credentialStoreFile = "credentials.enc"
+
+// credentialKeyService 系统钥匙串中保存密钥的服务名，账户名为凭据存储目录
+const credentialKeyService = "go-git-client-window"
+
+// CredentialKeyStore 保存凭据存储的加密密钥，密钥不能与密文放在一起以明文保存
+type CredentialKeyStore interface {
+	// LoadKey 读取密钥，尚未保存过时返回 fs.ErrNotExist
+	LoadKey() ([]byte, error)
+	// SaveKey 保存新生成的密钥
+	SaveKey(key []byte) error
+}
+
+// CredentialStore 以 AES-GCM 加密保存凭据的本地存储，密钥由 CredentialKeyStore（如系统钥匙串）保管
+type CredentialStore struct {
+	mu   sync.Mutex
+	path string
+	aead cipher.AEAD
+}
+
+// NewCredentialStore 打开或创建 dir 下的凭据存储，keys 为空时使用 NewSystemKeyStore(dir)
+func NewCredentialStore(dir string, keys CredentialKeyStore) (*CredentialStore, error) {
+	if strings.TrimSpace(dir) == "" {
+		return nil, fmt.Errorf("dir cannot be empty")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	if keys == nil {
+		keys = NewSystemKeyStore(dir)
+	}
+
+	path := filepath.Join(dir, credentialStoreFile)
+	key, err := loadCredentialKey(keys, path)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CredentialStore{path: path, aead: aead}, nil
+}
+
+// loadCredentialKey 读取密钥，不存在时生成新的 256 位密钥
+// 密钥丢失（如钥匙串被清空）时旧的密文已无法解密，一并删除
+func loadCredentialKey(keys CredentialKeyStore, storePath string) ([]byte, error) {
+	key, err := keys.LoadKey()
+	if err == nil {
+		if len(key) != 32 {
+			return nil, fmt.Errorf("invalid credential key length: %d", len(key))
+		}
+		return key, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	key = make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err := keys.SaveKey(key); err != nil {
+		return nil, err
+	}
+	if err := os.Remove(storePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Get 查找匹配的凭据，未找到时返回 nil
+// 按协议、主机与路径匹配，查询中带有用户名时还需用户名一致
+func (s *CredentialStore) Get(query models.GitCredential) (*models.GitCredential, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	creds, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	for _, cred := range creds {
+		if credentialMatches(cred, query) {
+			return &cred, nil
+		}
+	}
+	return nil, nil
+}
+
+// Save 保存凭据，覆盖同一目标和用户名的旧凭据
+func (s *CredentialStore) Save(cred models.GitCredential) error {
+	if cred.Host == "" || cred.Password == "" {
+		return fmt.Errorf("credential host and password cannot be empty")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	creds, err := s.load()
+	if err != nil {
+		return err
+	}
+	kept := []models.GitCredential{cred}
+	for _, existing := range creds {
+		if credentialKey(existing) != credentialKey(cred) {
+			kept = append(kept, existing)
+		}
+	}
+	return s.write(kept)
+}
+
+// Erase 删除匹配的凭据
+func (s *CredentialStore) Erase(query models.GitCredential) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	creds, err := s.load()
+	if err != nil {
+		return err
+	}
+	kept := []models.GitCredential{}
+	for _, cred := range creds {
+		if !credentialMatches(cred, query) {
+			kept = append(kept, cred)
+		}
+	}
+	if len(kept) == len(creds) {
+		return nil
+	}
+	return s.write(kept)
+}
+
+// Clear 删除全部已保存的凭据
+func (s *CredentialStore) Clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// credentialMatches 判断已保存的凭据是否满足查询
+func credentialMatches(cred, query models.GitCredential) bool {
+	return cred.Protocol == query.Protocol &&
+		cred.Host == query.Host &&
+		cred.Path == query.Path &&
+		(query.Username == "" || cred.Username == query.Username)
+}
+
+// load 解密读取全部凭据，文件不存在时为空
+func (s *CredentialStore) load() ([]models.GitCredential, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := s.aead.NonceSize()
+	if len(data) < nonceSize {
+		return nil, fmt.Errorf("credential store is corrupted: %s", s.path)
+	}
+	plain, err := s.aead.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("credential store is corrupted: %s", s.path)
+	}
+
+	var creds []models.GitCredential
+	if err := json.Unmarshal(plain, &creds); err != nil {
+		return nil, err
+	}
+	return creds, nil
+}
+
+// write 加密写入全部凭据，先写临时文件再替换，避免写入中断损坏存储
+func (s *CredentialStore) write(creds []models.GitCredential) error {
+	plain, err := json.Marshal(creds)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	data := s.aead.Seal(nonce, nonce, plain, nil)
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
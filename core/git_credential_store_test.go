@@ -0,0 +1,110 @@
+package core
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-git-client-window/models"
+)
+
+// memoryKeyStore 测试用的内存密钥存储
+type memoryKeyStore struct {
+	key []byte
+}
+
+func (s *memoryKeyStore) LoadKey() ([]byte, error) {
+	if s.key == nil {
+		return nil, fs.ErrNotExist
+	}
+	return s.key, nil
+}
+
+func (s *memoryKeyStore) SaveKey(key []byte) error {
+	s.key = key
+	return nil
+}
+
+func TestCredentialStore(t *testing.T) {
+	dir := t.TempDir()
+	keys := &memoryKeyStore{}
+	store, err := NewCredentialStore(dir, keys)
+	require.NoError(t, err)
+
+	alice := models.GitCredential{Protocol: "https", Host: "example.com", Username: "alice", Password: "token-1"}
+	bob := models.GitCredential{Protocol: "https", Host: "example.com", Username: "bob", Password: "token-2"}
+	require.NoError(t, store.Save(alice))
+	require.NoError(t, store.Save(bob))
+	assert.Error(t, store.Save(models.GitCredential{Host: "example.com"}))
+
+	// 凭据加密保存，目录中只有密文，密钥由密钥存储保管
+	data, err := os.ReadFile(filepath.Join(dir, credentialStoreFile))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "token-1")
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Len(t, keys.key, 32)
+
+	// 重新打开后使用同一密钥
+	store, err = NewCredentialStore(dir, keys)
+	require.NoError(t, err)
+	stored, err := store.Get(models.GitCredential{Protocol: "https", Host: "example.com", Username: "alice"})
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, "token-1", stored.Password)
+
+	stored, err = store.Get(models.GitCredential{Protocol: "ssh", Host: "example.com"})
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+
+	// 同一用户覆盖旧密码
+	alice.Password = "token-3"
+	require.NoError(t, store.Save(alice))
+	stored, err = store.Get(models.GitCredential{Protocol: "https", Host: "example.com", Username: "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, "token-3", stored.Password)
+
+	require.NoError(t, store.Erase(models.GitCredential{Protocol: "https", Host: "example.com", Username: "alice"}))
+	stored, err = store.Get(models.GitCredential{Protocol: "https", Host: "example.com"})
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, "bob", stored.Username)
+
+	require.NoError(t, store.Clear())
+	stored, err = store.Get(models.GitCredential{Protocol: "https", Host: "example.com"})
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+}
+
+func TestCredentialStoreKeyLost(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewCredentialStore(dir, &memoryKeyStore{})
+	require.NoError(t, err)
+	require.NoError(t, store.Save(models.GitCredential{Protocol: "https", Host: "example.com", Username: "alice", Password: "token-1"}))
+
+	// 密钥丢失后旧密文无法解密，重新生成密钥并丢弃旧数据
+	store, err = NewCredentialStore(dir, &memoryKeyStore{})
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, credentialStoreFile))
+	stored, err := store.Get(models.GitCredential{Protocol: "https", Host: "example.com"})
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+
+	_, err = NewCredentialStore(dir, &memoryKeyStore{key: []byte("short")})
+	assert.Error(t, err)
+}
+
+func TestCredentialStoreCorrupted(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewCredentialStore(dir, &memoryKeyStore{})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, credentialStoreFile), []byte("not encrypted"), 0o600))
+
+	_, err = store.Get(models.GitCredential{Protocol: "https", Host: "example.com"})
+	assert.Error(t, err)
+}
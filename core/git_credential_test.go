@@ -0,0 +1,170 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-git-client-window/models"
+)
+
+// setCredentialClientEnv 让当前进程以客户端模式连接 bridge
+func setCredentialClientEnv(t *testing.T, bridge *CredentialBridge) {
+	t.Helper()
+	for _, kv := range bridge.Env() {
+		key, value, _ := strings.Cut(kv, "=")
+		if key == credentialAddrEnv || key == credentialTokenEnv {
+			t.Setenv(key, value)
+		}
+	}
+}
+
+func runCredentialClient(t *testing.T, input string, args ...string) (string, int) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := RunCredentialClient(args, strings.NewReader(input), &stdout, &stderr)
+	return stdout.String(), code
+}
+
+func TestCredentialBridgeHelper(t *testing.T) {
+	store, err := NewCredentialStore(t.TempDir(), &memoryKeyStore{})
+	require.NoError(t, err)
+
+	var requests []models.GitCredentialRequest
+	response := models.GitCredentialResponse{Username: "alice", Password: "token-1", Remember: true}
+	bridge, err := NewCredentialBridge("/opt/git client/app", func(ctx context.Context, req models.GitCredentialRequest) (*models.GitCredentialResponse, error) {
+		requests = append(requests, req)
+		return &response, nil
+	}, store)
+	require.NoError(t, err)
+	defer bridge.Close()
+	setCredentialClientEnv(t, bridge)
+
+	env := strings.Join(bridge.Env(), "\n")
+	assert.Contains(t, env, "credential.helper")
+	assert.Contains(t, env, "!'/opt/git client/app' credential")
+	assert.Contains(t, env, "GIT_ASKPASS=/opt/git client/app")
+
+	input := "protocol=https\nhost=example.com\n\n"
+	out, code := runCredentialClient(t, input, "credential", "get")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "username=alice\npassword=token-1\n", out)
+	require.Len(t, requests, 1)
+	assert.Equal(t, models.CredentialKindCredential, requests[0].Kind)
+	assert.Equal(t, "example.com", requests[0].Host)
+	assert.True(t, requests[0].Secret)
+	assert.True(t, requests[0].CanRemember)
+
+	// 认证成功后 git 调用 store，选择记住的凭据被保存，之后不再提示
+	_, code = runCredentialClient(t, "protocol=https\nhost=example.com\nusername=alice\npassword=token-1\n", "credential", "store")
+	assert.Equal(t, 0, code)
+	out, _ = runCredentialClient(t, input, "credential", "get")
+	assert.Equal(t, "username=alice\npassword=token-1\n", out)
+	assert.Len(t, requests, 1)
+
+	// 认证失败后 git 调用 erase，已保存的凭据被删除
+	_, code = runCredentialClient(t, "protocol=https\nhost=example.com\nusername=alice\npassword=token-1\n", "credential", "erase")
+	assert.Equal(t, 0, code)
+	stored, err := store.Get(models.GitCredential{Protocol: "https", Host: "example.com"})
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+
+	// 用户取消时让 git 停止尝试
+	response = models.GitCredentialResponse{Canceled: true}
+	out, code = runCredentialClient(t, input, "credential", "get")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "quit=1\n", out)
+	assert.Equal(t, ErrKindCanceled, ClassifyGitError("fatal: credential helper '!app credential' told us to quit"))
+
+	// 桥接不可用（如应用已退出）时同样让 git 停止尝试
+	t.Setenv(credentialTokenEnv, "wrong")
+	out, code = runCredentialClient(t, input, "credential", "get")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "quit=1\n", out)
+
+	// 不认识的动作按协议忽略
+	_, code = runCredentialClient(t, "", "credential", "capability")
+	assert.Equal(t, 0, code)
+}
+
+func TestCredentialBridgeAskpass(t *testing.T) {
+	var request models.GitCredentialRequest
+	bridge, err := NewCredentialBridge("app", func(ctx context.Context, req models.GitCredentialRequest) (*models.GitCredentialResponse, error) {
+		request = req
+		return &models.GitCredentialResponse{Answer: "secret", Canceled: strings.Contains(req.Prompt, "yes/no")}, nil
+	}, nil)
+	require.NoError(t, err)
+	defer bridge.Close()
+	setCredentialClientEnv(t, bridge)
+
+	out, code := runCredentialClient(t, "", "Enter passphrase for key '/home/u/.ssh/id_ed25519': ")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "secret\n", out)
+	assert.Equal(t, models.CredentialKindAskpass, request.Kind)
+	assert.True(t, request.Secret)
+
+	_, code = runCredentialClient(t, "", "Are you sure you want to continue connecting (yes/no/[fingerprint])? ")
+	assert.Equal(t, 1, code)
+	assert.False(t, request.Secret)
+}
+
+func TestCredentialBridgeRejectsBadToken(t *testing.T) {
+	bridge, err := NewCredentialBridge("app", func(ctx context.Context, req models.GitCredentialRequest) (*models.GitCredentialResponse, error) {
+		t.Error("prompt should not be called")
+		return nil, nil
+	}, nil)
+	require.NoError(t, err)
+	defer bridge.Close()
+	setCredentialClientEnv(t, bridge)
+	t.Setenv(credentialTokenEnv, "wrong")
+
+	_, code := runCredentialClient(t, "", "Password: ")
+	assert.Equal(t, 1, code)
+}
+
+func TestCredentialBridgeClientDisconnect(t *testing.T) {
+	canceled := make(chan struct{})
+	bridge, err := NewCredentialBridge("app", func(ctx context.Context, req models.GitCredentialRequest) (*models.GitCredentialResponse, error) {
+		<-ctx.Done()
+		close(canceled)
+		return nil, ctx.Err()
+	}, nil)
+	require.NoError(t, err)
+	setCredentialClientEnv(t, bridge)
+
+	type clientResult struct {
+		out  string
+		code int
+	}
+	done := make(chan clientResult)
+	go func() {
+		out, code := runCredentialClient(t, "protocol=https\nhost=example.com\n\n", "credential", "get")
+		done <- clientResult{out, code}
+	}()
+
+	// 关闭桥接时等待中的提示被取消，credential get 输出 quit=1 避免 git 退回到 askpass 再次提示
+	time.Sleep(100 * time.Millisecond)
+	bridge.Close()
+	select {
+	case <-canceled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("prompt was not canceled")
+	}
+	result := <-done
+	assert.Equal(t, 0, result.code)
+	assert.Equal(t, "quit=1\n", result.out)
+}
+
+func TestParseCredential(t *testing.T) {
+	cred, err := ParseCredential(strings.NewReader("protocol=https\r\nhost=example.com:8443\npath=org/repo.git\nusername=bob\nwwwauth[]=Basic\n\nignored=1\n"))
+	require.NoError(t, err)
+	assert.Equal(t, models.GitCredential{Protocol: "https", Host: "example.com:8443", Path: "org/repo.git", Username: "bob"}, cred)
+
+	_, err = ParseCredential(strings.NewReader("garbage\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,270 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go-git-client-window/models"
+)
+
+// diffBaseArgs 生成可解析 diff 输出的公共参数
+var diffBaseArgs = []string{"--no-color", "--no-ext-diff", "--find-renames"}
+
+// hunkHeaderPattern 例: @@ -1,3 +1,4 @@ func main() {
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@ ?(.*)$`)
+
+// runDiff 执行 diff 类命令（diff/show/stash show 等）并解析为结构化差异
+// command 为子命令（如 ["stash", "show", "-p"]），公共参数会插入到子命令与 args 之间
+func runDiff(repoPath string, command []string, args ...string) ([]models.FileDiff, error) {
+	fullArgs := append(append([]string{}, command...), diffBaseArgs...)
+	fullArgs = append(fullArgs, args...)
+
+	result, err := runGit(repoPath, fullArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseUnifiedDiff(result.Stdout)
+}
+
+// ParseUnifiedDiff 解析 git 的统一差异格式输出
+func ParseUnifiedDiff(output string) ([]models.FileDiff, error) {
+	files := []models.FileDiff{}
+	lines := strings.Split(output, "\n")
+	// 去掉输出末尾换行产生的空元素
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	var file *models.FileDiff
+	flush := func() {
+		if file != nil {
+			finishFileDiff(file)
+			files = append(files, *file)
+			file = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if strings.HasPrefix(line, "diff --git ") {
+			flush()
+			oldPath, newPath := parseDiffGitHeader(strings.TrimPrefix(line, "diff --git "))
+			file = &models.FileDiff{OldPath: oldPath, NewPath: newPath, Hunks: []models.DiffHunk{}}
+			continue
+		}
+		if file == nil {
+			// diff 之前的内容（如 git show 的提交信息）直接跳过
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "@@ "):
+			hunk, consumed, err := parseHunk(lines[i:])
+			if err != nil {
+				return nil, err
+			}
+			for _, diffLine := range hunk.Lines {
+				switch diffLine.Kind {
+				case models.DiffLineAdd:
+					file.Additions++
+				case models.DiffLineDelete:
+					file.Deletions++
+				}
+			}
+			file.Hunks = append(file.Hunks, hunk)
+			i += consumed - 1
+		case strings.HasPrefix(line, "old mode "):
+			file.OldMode = strings.TrimPrefix(line, "old mode ")
+		case strings.HasPrefix(line, "new mode "):
+			file.NewMode = strings.TrimPrefix(line, "new mode ")
+		case strings.HasPrefix(line, "new file mode "):
+			file.Status = "A"
+			file.NewMode = strings.TrimPrefix(line, "new file mode ")
+			file.OldPath = ""
+		case strings.HasPrefix(line, "deleted file mode "):
+			file.Status = "D"
+			file.OldMode = strings.TrimPrefix(line, "deleted file mode ")
+			file.NewPath = ""
+		case strings.HasPrefix(line, "similarity index "):
+			file.Similarity, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(line, "similarity index "), "%"))
+		case strings.HasPrefix(line, "rename from "):
+			file.Status = "R"
+			file.OldPath = unquoteGitPath(strings.TrimPrefix(line, "rename from "))
+		case strings.HasPrefix(line, "rename to "):
+			file.NewPath = unquoteGitPath(strings.TrimPrefix(line, "rename to "))
+		case strings.HasPrefix(line, "copy from "):
+			file.Status = "C"
+			file.OldPath = unquoteGitPath(strings.TrimPrefix(line, "copy from "))
+		case strings.HasPrefix(line, "copy to "):
+			file.NewPath = unquoteGitPath(strings.TrimPrefix(line, "copy to "))
+		case strings.HasPrefix(line, "index "):
+			// index abc123..def456 100644
+			fields := strings.Fields(line)
+			if len(fields) == 3 && file.OldMode == "" && file.NewMode == "" {
+				file.OldMode, file.NewMode = fields[2], fields[2]
+			}
+		case strings.HasPrefix(line, "Binary files ") || line == "GIT binary patch":
+			file.Binary = true
+		case strings.HasPrefix(line, "--- "):
+			if path := parseDiffPath(strings.TrimPrefix(line, "--- "), "a/"); path != "" {
+				file.OldPath = path
+			}
+		case strings.HasPrefix(line, "+++ "):
+			if path := parseDiffPath(strings.TrimPrefix(line, "+++ "), "b/"); path != "" {
+				file.NewPath = path
+			}
+		}
+	}
+	flush()
+
+	return files, nil
+}
+
+// parseHunk 从 @@ 行开始解析一个差异块，返回消耗的行数
+// 依据头部声明的行数判断结束位置，避免把以 "--- " 开头的删除行误判为文件头
+func parseHunk(lines []string) (models.DiffHunk, int, error) {
+	m := hunkHeaderPattern.FindStringSubmatch(lines[0])
+	if m == nil {
+		return models.DiffHunk{}, 0, fmt.Errorf("invalid hunk header: %q", lines[0])
+	}
+
+	hunk := models.DiffHunk{
+		Header:   lines[0],
+		OldStart: atoiDefault(m[1], 0),
+		OldLines: atoiDefault(m[2], 1),
+		NewStart: atoiDefault(m[3], 0),
+		NewLines: atoiDefault(m[4], 1),
+		Section:  m[5],
+		Lines:    []models.DiffLine{},
+	}
+
+	oldLine, newLine := hunk.OldStart, hunk.NewStart
+	oldRemaining, newRemaining := hunk.OldLines, hunk.NewLines
+	consumed := 1
+
+	for ; consumed < len(lines); consumed++ {
+		line := lines[consumed]
+
+		if strings.HasPrefix(line, `\`) {
+			// \ No newline at end of file 作用于上一行
+			if n := len(hunk.Lines); n > 0 {
+				hunk.Lines[n-1].NoNewline = true
+			}
+			continue
+		}
+		if oldRemaining <= 0 && newRemaining <= 0 {
+			break
+		}
+
+		prefix, content := byte(' '), ""
+		if line != "" {
+			prefix, content = line[0], line[1:]
+		}
+
+		switch prefix {
+		case ' ':
+			hunk.Lines = append(hunk.Lines, models.DiffLine{Kind: models.DiffLineContext, Content: content, OldLine: oldLine, NewLine: newLine})
+			oldLine++
+			newLine++
+			oldRemaining--
+			newRemaining--
+		case '-':
+			hunk.Lines = append(hunk.Lines, models.DiffLine{Kind: models.DiffLineDelete, Content: content, OldLine: oldLine})
+			oldLine++
+			oldRemaining--
+		case '+':
+			hunk.Lines = append(hunk.Lines, models.DiffLine{Kind: models.DiffLineAdd, Content: content, NewLine: newLine})
+			newLine++
+			newRemaining--
+		default:
+			return hunk, consumed, nil
+		}
+	}
+
+	return hunk, consumed, nil
+}
+
+// finishFileDiff 补全状态与展示用文件名
+func finishFileDiff(file *models.FileDiff) {
+	if file.Status == "" {
+		file.Status = "M"
+	}
+	file.Filename = file.NewPath
+	if file.Filename == "" {
+		file.Filename = file.OldPath
+	}
+}
+
+// parseDiffGitHeader 解析 "diff --git a/x b/y" 中的路径
+func parseDiffGitHeader(rest string) (string, string) {
+	if strings.HasPrefix(rest, `"`) {
+		oldPath, remaining := readQuotedPath(rest)
+		newPath := strings.TrimSpace(remaining)
+		return strings.TrimPrefix(oldPath, "a/"), strings.TrimPrefix(unquoteGitPath(newPath), "b/")
+	}
+	if i := strings.Index(rest, ` "b/`); i >= 0 {
+		return strings.TrimPrefix(rest[:i], "a/"), strings.TrimPrefix(unquoteGitPath(rest[i+1:]), "b/")
+	}
+	// 未加引号且新旧路径相同时 "a/<p> b/<p>" 两半长度相等；重命名路径会被后续 rename 行覆盖
+	if len(rest)%2 == 1 {
+		half := len(rest) / 2
+		oldPath, newPath := rest[:half], rest[half+1:]
+		if strings.TrimPrefix(oldPath, "a/") == strings.TrimPrefix(newPath, "b/") {
+			return strings.TrimPrefix(oldPath, "a/"), strings.TrimPrefix(newPath, "b/")
+		}
+	}
+	if i := strings.Index(rest, " b/"); i >= 0 {
+		return strings.TrimPrefix(rest[:i], "a/"), rest[i+3:]
+	}
+	return rest, rest
+}
+
+// parseDiffPath 解析 ---/+++ 行中的路径，/dev/null 返回空
+func parseDiffPath(value, prefix string) string {
+	value = strings.TrimSuffix(value, "\t")
+	if value == "/dev/null" {
+		return ""
+	}
+	return strings.TrimPrefix(unquoteGitPath(value), prefix)
+}
+
+// readQuotedPath 读取开头的 C 风格引号路径，返回解码后的路径与剩余部分
+func readQuotedPath(s string) (string, string) {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return unquoteGitPath(s[:i+1]), s[i+1:]
+		}
+	}
+	return s, ""
+}
+
+// unquoteGitPath 解码 git 对特殊字符路径使用的 C 风格引号（含 \ooo 八进制转义）
+func unquoteGitPath(path string) string {
+	if len(path) < 2 || !strings.HasPrefix(path, `"`) || !strings.HasSuffix(path, `"`) {
+		return path
+	}
+	unquoted, err := strconv.Unquote(path)
+	if err != nil {
+		return path
+	}
+	return unquoted
+}
+
+// atoiDefault 字符串转整数，空字符串返回默认值
+func atoiDefault(s string, def int) int {
+	if s == "" {
+		return def
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return def
+	}
+	return n
+}
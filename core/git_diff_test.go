@@ -0,0 +1,114 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-git-client-window/models"
+)
+
+const sampleDiff = `diff --git a/main.go b/main.go
+index 83db48f..bf269f4 100644
+--- a/main.go
++++ b/main.go
+@@ -1,4 +1,4 @@ package main
+ line one
+--- removed line that looks like a header
++++ added line that looks like a header
+ line three
+ line four
+diff --git a/old name.txt b/new name.txt
+similarity index 90%
+rename from old name.txt
+rename to new name.txt
+index 1111111..2222222 100644
+--- a/old name.txt
++++ b/new name.txt
+@@ -1 +1 @@
+-old
+\ No newline at end of file
++new
+diff --git a/script.sh b/script.sh
+old mode 100644
+new mode 100755
+diff --git a/image.png b/image.png
+new file mode 100644
+index 0000000..3333333
+Binary files /dev/null and b/image.png differ
+diff --git "a/t\303\251st.txt" "b/t\303\251st.txt"
+deleted file mode 100644
+index 4444444..0000000
+--- "a/t\303\251st.txt"
++++ /dev/null
+@@ -1,2 +0,0 @@
+-a
+-b
+`
+
+func TestParseUnifiedDiff(t *testing.T) {
+	files, err := ParseUnifiedDiff(sampleDiff)
+	require.NoError(t, err)
+	require.Len(t, files, 5)
+
+	modified := files[0]
+	assert.Equal(t, "main.go", modified.Filename)
+	assert.Equal(t, "M", modified.Status)
+	assert.Equal(t, "100644", modified.NewMode)
+	assert.Equal(t, 1, modified.Additions)
+	assert.Equal(t, 1, modified.Deletions)
+	require.Len(t, modified.Hunks, 1)
+	hunk := modified.Hunks[0]
+	assert.Equal(t, "package main", hunk.Section)
+	assert.Equal(t, []models.DiffLine{
+		{Kind: models.DiffLineContext, Content: "line one", OldLine: 1, NewLine: 1},
+		{Kind: models.DiffLineDelete, Content: "-- removed line that looks like a header", OldLine: 2},
+		{Kind: models.DiffLineAdd, Content: "++ added line that looks like a header", NewLine: 2},
+		{Kind: models.DiffLineContext, Content: "line three", OldLine: 3, NewLine: 3},
+		{Kind: models.DiffLineContext, Content: "line four", OldLine: 4, NewLine: 4},
+	}, hunk.Lines)
+
+	renamed := files[1]
+	assert.Equal(t, "R", renamed.Status)
+	assert.Equal(t, "old name.txt", renamed.OldPath)
+	assert.Equal(t, "new name.txt", renamed.NewPath)
+	assert.Equal(t, 90, renamed.Similarity)
+	require.Len(t, renamed.Hunks[0].Lines, 2)
+	assert.True(t, renamed.Hunks[0].Lines[0].NoNewline)
+
+	modeChange := files[2]
+	assert.Equal(t, "script.sh", modeChange.Filename)
+	assert.Equal(t, "100644", modeChange.OldMode)
+	assert.Equal(t, "100755", modeChange.NewMode)
+	assert.Empty(t, modeChange.Hunks)
+
+	binary := files[3]
+	assert.Equal(t, "A", binary.Status)
+	assert.True(t, binary.Binary)
+	assert.Equal(t, "image.png", binary.Filename)
+
+	deleted := files[4]
+	assert.Equal(t, "D", deleted.Status)
+	assert.Equal(t, "tést.txt", deleted.Filename)
+	assert.Equal(t, 2, deleted.Deletions)
+}
+
+func TestGetFileDiff(t *testing.T) {
+	dir := newTestRepo(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("hello\nworld\n"), 0o644))
+
+	diffs, err := gitService.GetFileDiff(dir, "README.md", false)
+	require.NoError(t, err)
+	require.Len(t, diffs, 1)
+	require.Len(t, diffs[0].Hunks, 1)
+	assert.Equal(t, 1, diffs[0].Additions)
+	last := diffs[0].Hunks[0].Lines[len(diffs[0].Hunks[0].Lines)-1]
+	assert.Equal(t, models.DiffLine{Kind: models.DiffLineAdd, Content: "world", NewLine: 2}, last)
+
+	staged, err := gitService.GetFileDiff(dir, "README.md", true)
+	require.NoError(t, err)
+	assert.Empty(t, staged)
+}
@@ -0,0 +1,199 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// GitErrorKind Git 错误分类
+type GitErrorKind string
+
+const (
+	ErrKindUnknown            GitErrorKind = "Unknown"            // 未识别的错误
+	ErrKindGitUnavailable     GitErrorKind = "GitUnavailable"     // 无法启动 git 可执行文件
+	ErrKindCanceled           GitErrorKind = "Canceled"           // 用户取消
+	ErrKindTimeout            GitErrorKind = "Timeout"            // 操作超时
+	ErrKindNotARepo           GitErrorKind = "NotARepo"           // 不是 Git 仓库
+	ErrKindConflict           GitErrorKind = "Conflict"           // 合并/变基/拣选冲突
+	ErrKindAuthFailed         GitErrorKind = "AuthFailed"         // 认证失败
+	ErrKindRejected           GitErrorKind = "Rejected"           // 推送被拒绝（非快进等）
+	ErrKindDirtyWorktree      GitErrorKind = "DirtyWorktree"      // 工作区有未提交的改动
+	ErrKindLockHeld           GitErrorKind = "LockHeld"           // 其他 git 进程持有锁
+	ErrKindNetworkUnreachable GitErrorKind = "NetworkUnreachable" // 网络不可达
+	ErrKindRemoteNotFound     GitErrorKind = "RemoteNotFound"     // 远程仓库不存在
+	ErrKindUnknownRevision    GitErrorKind = "UnknownRevision"    // 引用或路径不存在
+	ErrKindNoUpstream         GitErrorKind = "NoUpstream"         // 未配置上游分支
+	ErrKindNothingToCommit    GitErrorKind = "NothingToCommit"    // 没有可提交的内容
+	ErrKindAlreadyExists      GitErrorKind = "AlreadyExists"      // 分支、标签等已存在
+	ErrKindNotFullyMerged     GitErrorKind = "NotFullyMerged"     // 删除的分支包含未合并的提交
+	ErrKindProtectedBranch    GitErrorKind = "ProtectedBranch"    // 禁止强制推送或删除受保护分支
+)
+
+// GitError 结构化的 Git 错误
+type GitError struct {
+	Kind     GitErrorKind `json:"kind"`
+	Message  string       `json:"message"`  // 简要描述，通常为 stderr 中的 fatal/error 行
+	Command  string       `json:"command"`  // 执行的完整命令
+	ExitCode int          `json:"exitCode"` // 退出码，未能启动时为 -1
+	Stderr   string       `json:"stderr"`
+	Err      error        `json:"-"`
+}
+
+// Error 实现 error 接口
+func (e *GitError) Error() string {
+	if e.Command == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Command, e.Message)
+}
+
+// Unwrap 返回底层错误，便于 errors.Is 判断 context.Canceled 等
+func (e *GitError) Unwrap() error {
+	return e.Err
+}
+
+// errorPattern 错误分类规则
+type errorPattern struct {
+	kind     GitErrorKind
+	patterns []string
+}
+
+// errorPatterns 按优先级排列的分类规则（均为小写），先匹配的优先
+var errorPatterns = []errorPattern{
+	{ErrKindNotARepo, []string{"not a git repository"}},
+	{ErrKindLockHeld, []string{".lock': file exists", "another git process seems to be running"}},
+	{ErrKindConflict, []string{"conflict (", "automatic merge failed", "could not apply", "you have unmerged paths", "unmerged files", "needs merge", "fix conflicts"}},
+	{ErrKindDirtyWorktree, []string{
+		"your local changes to the following files would be overwritten",
+		"untracked working tree files would be overwritten",
+		"please commit your changes or stash them",
+		"you have unstaged changes",
+		"your index contains uncommitted changes",
+	}},
+	{ErrKindCanceled, []string{"told us to quit"}}, // 用户在凭据提示中取消
+	{ErrKindAuthFailed, []string{
+		"authentication failed",
+		"permission denied (publickey",
+		"could not read username",
+		"could not read password",
+		"invalid username or password",
+		"terminal prompts disabled",
+		"host key verification failed",
+		"the requested url returned error: 401",
+		"the requested url returned error: 403",
+	}},
+	{ErrKindRemoteNotFound, []string{"repository not found", "does not appear to be a git repository", "the requested url returned error: 404"}},
+	{ErrKindNetworkUnreachable, []string{
+		"could not resolve host",
+		"failed to connect",
+		"connection timed out",
+		"connection refused",
+		"network is unreachable",
+		"operation timed out",
+		"could not read from remote repository",
+		"unable to access",
+	}},
+	// 删除不存在的远程引用同样会输出 "failed to push some refs"，需先于 Rejected 匹配
+	{ErrKindUnknownRevision, []string{"remote ref does not exist"}},
+	{ErrKindRejected, []string{"[rejected]", "[remote rejected]", "non-fast-forward", "updates were rejected", "stale info", "failed to push some refs"}},
+	{ErrKindNoUpstream, []string{"has no upstream branch", "no tracking information"}},
+	{ErrKindUnknownRevision, []string{
+		"unknown revision",
+		"bad revision",
+		"ambiguous argument",
+		"invalid reference",
+		"not a valid object name",
+		"needed a single revision",
+		"did not match any file(s) known to git",
+		"couldn't find remote ref",
+		"invalid upstream",
+		"not a commit",
+	}},
+	{ErrKindNothingToCommit, []string{"nothing to commit", "no changes added to commit"}},
+	{ErrKindAlreadyExists, []string{"already exists"}},
+	{ErrKindNotFullyMerged, []string{"is not fully merged"}},
+}
+
+// ClassifyGitError 根据 git 输出判断错误类型
+func ClassifyGitError(output string) GitErrorKind {
+	lower := strings.ToLower(output)
+	for _, rule := range errorPatterns {
+		for _, pattern := range rule.patterns {
+			if strings.Contains(lower, pattern) {
+				return rule.kind
+			}
+		}
+	}
+	return ErrKindUnknown
+}
+
+// newGitError 根据执行结果构造 GitError
+func newGitError(args []string, result *GitCommandResult, err error) *GitError {
+	gitErr := &GitError{
+		Command:  "git " + strings.Join(args, " "),
+		ExitCode: result.ExitCode,
+		Stderr:   result.Stderr,
+		Err:      err,
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		gitErr.Kind = ErrKindCanceled
+		gitErr.Message = "operation canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		gitErr.Kind = ErrKindTimeout
+		gitErr.Message = "operation timed out"
+	case result.ExitCode < 0:
+		gitErr.Kind = ErrKindGitUnavailable
+		gitErr.Message = err.Error()
+	default:
+		// 部分命令（如 merge、commit）把冲突或提示信息写到 stdout
+		gitErr.Kind = ClassifyGitError(result.Stderr + "\n" + result.Stdout)
+		gitErr.Message = summarizeGitOutput(result.Stderr, result.Stdout)
+		if gitErr.Message == "" {
+			gitErr.Message = err.Error()
+		}
+	}
+
+	return gitErr
+}
+
+// summarizeGitOutput 提取最能说明错误的一行：优先 fatal/error 行，否则取第一行非空输出
+func summarizeGitOutput(outputs ...string) string {
+	var first string
+	for _, output := range outputs {
+		for _, line := range strings.Split(output, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			if strings.HasPrefix(line, "fatal:") || strings.HasPrefix(line, "error:") {
+				return line
+			}
+			if first == "" {
+				first = line
+			}
+		}
+	}
+	return first
+}
+
+// AsGitError 将任意错误转换为 GitError，非 Git 错误归类为 Unknown
+func AsGitError(err error) *GitError {
+	if err == nil {
+		return nil
+	}
+	var gitErr *GitError
+	if errors.As(err, &gitErr) {
+		return gitErr
+	}
+	return &GitError{Kind: ErrKindUnknown, Message: err.Error(), ExitCode: -1, Err: err}
+}
+
+// IsGitErrorKind 判断错误是否为指定类型
+func IsGitErrorKind(err error, kind GitErrorKind) bool {
+	var gitErr *GitError
+	return errors.As(err, &gitErr) && gitErr.Kind == kind
+}
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyGitError(t *testing.T) {
+	tests := []struct {
+		output string
+		want   GitErrorKind
+	}{
+		{"fatal: not a git repository (or any of the parent directories): .git", ErrKindNotARepo},
+		{"error: The branch 'topic' is not fully merged.\nIf you are sure you want to delete it, run 'git branch -D topic'.", ErrKindNotFullyMerged},
+		{"error: unable to delete 'topic': remote ref does not exist\nerror: failed to push some refs to 'origin'", ErrKindUnknownRevision},
+		{"CONFLICT (content): Merge conflict in a.txt\nAutomatic merge failed; fix conflicts and then commit the result.", ErrKindConflict},
+		{"remote: Invalid username or password.\nfatal: Authentication failed for 'https://example.com/repo.git/'", ErrKindAuthFailed},
+		{"git@github.com: Permission denied (publickey).\nfatal: Could not read from remote repository.", ErrKindAuthFailed},
+		{" ! [rejected]        main -> main (non-fast-forward)\nerror: failed to push some refs", ErrKindRejected},
+		{"error: Your local changes to the following files would be overwritten by checkout:\n\ta.txt", ErrKindDirtyWorktree},
+		{"fatal: Unable to create '/repo/.git/index.lock': File exists.", ErrKindLockHeld},
+		{"fatal: unable to access 'https://example.com/': Could not resolve host: example.com", ErrKindNetworkUnreachable},
+		{"remote: Repository not found.\nfatal: repository 'https://example.com/x.git/' not found", ErrKindRemoteNotFound},
+		{"fatal: ambiguous argument 'nope': unknown revision or path not in the working tree.", ErrKindUnknownRevision},
+		{"fatal: a branch named 'dev' already exists", ErrKindAlreadyExists},
+		{"something unexpected", ErrKindUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.want), func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyGitError(tt.output))
+		})
+	}
+}
+
+func TestGitErrorFromCommand(t *testing.T) {
+	t.Run("非仓库目录", func(t *testing.T) {
+		_, err := gitService.GetCurrentBranch(t.TempDir())
+		var gitErr *GitError
+		assert.True(t, errors.As(err, &gitErr))
+		assert.Equal(t, ErrKindNotARepo, gitErr.Kind)
+		assert.Equal(t, 128, gitErr.ExitCode)
+		assert.Equal(t, "git rev-parse --abbrev-ref HEAD", gitErr.Command)
+		assert.Contains(t, gitErr.Message, "fatal: not a git repository")
+	})
+
+	t.Run("未知引用", func(t *testing.T) {
+		dir := newTestRepo(t)
+		_, err := gitService.Checkout(dir, "no-such-branch")
+		assert.True(t, IsGitErrorKind(err, ErrKindUnknownRevision))
+	})
+
+	t.Run("非 Git 错误", func(t *testing.T) {
+		gitErr := AsGitError(errors.New("path cannot be empty"))
+		assert.Equal(t, ErrKindUnknown, gitErr.Kind)
+		assert.Equal(t, "path cannot be empty", gitErr.Error())
+	})
+}
@@ -0,0 +1,199 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go-git-client-window/models"
+)
+
+// DefaultGraphPageSize 提交图默认每页行数
+const DefaultGraphPageSize = 200
+
+// graphFormat 提交图读取格式：哈希、父提交、引用、标题、作者、日期
+const graphFormat = "%H\x1f%P\x1f%D\x1f%s\x1f%an\x1f%ci"
+
+// GetCommitGraph 获取带列布局与连线的提交图
+// 列布局依赖之前的所有行，因此每页都会从头计算到 Skip+Limit，再截取本页
+func (s *GitCoreService) GetCommitGraph(repoPath string, opts models.GitGraphOptions) (*models.GitGraphPage, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+	if opts.Skip < 0 {
+		return nil, fmt.Errorf("skip cannot be negative")
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultGraphPageSize
+	}
+	if err := checkRevisionArgs(opts.Refs); err != nil {
+		return nil, err
+	}
+
+	// 多读一条用于判断是否还有下一页
+	args := []string{"log", "--date-order", "--format=" + graphFormat, "-n", strconv.Itoa(opts.Skip + opts.Limit + 1)}
+	if opts.All {
+		args = append(args, "--all")
+	}
+	args = append(args, opts.Refs...)
+	args = append(args, "--")
+
+	result, err := runGit(repoPath, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []graphCommit
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		if line == "" {
+			continue
+		}
+		commit, err := parseGraphLine(line)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, commit)
+	}
+
+	page := &models.GitGraphPage{Rows: []models.GitGraphRow{}, Skip: opts.Skip}
+	if len(commits) > opts.Skip+opts.Limit {
+		page.HasMore = true
+		commits = commits[:opts.Skip+opts.Limit]
+	}
+
+	rows := layoutCommitGraph(commits)
+	if opts.Skip < len(rows) {
+		page.Rows = rows[opts.Skip:]
+	}
+
+	return page, nil
+}
+
+// graphCommit 参与布局的提交
+type graphCommit struct {
+	record  models.GitCommitRecord
+	parents []string
+}
+
+// parseGraphLine 解析 graphFormat 格式的一行
+func parseGraphLine(line string) (graphCommit, error) {
+	parts := strings.Split(line, "\x1f")
+	if len(parts) < 6 {
+		return graphCommit{}, fmt.Errorf("invalid graph line format: %s", line)
+	}
+
+	branches, tags := ParseRefNames(parts[2])
+	return graphCommit{
+		record: models.GitCommitRecord{
+			Hash:     parts[0],
+			Message:  parts[3],
+			Author:   parts[4],
+			Date:     parts[5],
+			Branches: branches,
+			Tags:     tags,
+		},
+		parents: strings.Fields(parts[1]),
+	}, nil
+}
+
+// layoutCommitGraph 为按子先于父排序的提交分配列并计算连线
+// lanes 记录每一列正在等待的提交哈希，空字符串表示该列空闲
+func layoutCommitGraph(commits []graphCommit) []models.GitGraphRow {
+	rows := make([]models.GitGraphRow, 0, len(commits))
+	var lanes []string
+
+	for _, commit := range commits {
+		hash := commit.record.Hash
+		row := models.GitGraphRow{
+			Commit:   commit.record,
+			Parents:  commit.parents,
+			Lane:     -1,
+			IsMerge:  len(commit.parents) > 1,
+			EdgesIn:  []models.GitGraphEdge{},
+			EdgesOut: []models.GitGraphEdge{},
+		}
+
+		// 等待本提交的列汇入同一节点，其余列直通
+		for i, waiting := range lanes {
+			switch {
+			case waiting == hash:
+				if row.Lane < 0 {
+					row.Lane = i
+				}
+				row.EdgesIn = append(row.EdgesIn, models.GitGraphEdge{From: i, To: row.Lane})
+				lanes[i] = ""
+			case waiting != "":
+				row.EdgesIn = append(row.EdgesIn, models.GitGraphEdge{From: i, To: i})
+			}
+		}
+		if row.Lane < 0 {
+			// 分支顶端：占用第一个空闲列
+			row.Lane = freeLane(lanes, -1)
+			if row.Lane == len(lanes) {
+				lanes = append(lanes, "")
+			}
+		}
+
+		// 直通列在下半行继续向下
+		for i, waiting := range lanes {
+			if waiting != "" {
+				row.EdgesOut = append(row.EdgesOut, models.GitGraphEdge{From: i, To: i})
+			}
+		}
+
+		// 第一个父提交沿用当前列，其余父提交复用已有列或占用新列
+		for n, parent := range commit.parents {
+			target := row.Lane
+			if n > 0 {
+				target = indexOf(lanes, parent)
+				if target < 0 {
+					target = freeLane(lanes, row.Lane)
+					if target == len(lanes) {
+						lanes = append(lanes, "")
+					}
+				}
+			}
+			if lanes[target] == "" {
+				lanes[target] = parent
+			}
+			row.EdgesOut = append(row.EdgesOut, models.GitGraphEdge{From: row.Lane, To: target})
+		}
+
+		for len(lanes) > 0 && lanes[len(lanes)-1] == "" {
+			lanes = lanes[:len(lanes)-1]
+		}
+		row.Width = max(len(lanes), row.Lane+1, maxEdgeLane(row.EdgesIn)+1)
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// freeLane 返回第一个空闲列（跳过 exclude），没有空闲列时返回 len(lanes)
+func freeLane(lanes []string, exclude int) int {
+	for i, waiting := range lanes {
+		if waiting == "" && i != exclude {
+			return i
+		}
+	}
+	return len(lanes)
+}
+
+// indexOf 返回哈希所在列，不存在时返回 -1
+func indexOf(lanes []string, hash string) int {
+	for i, waiting := range lanes {
+		if waiting == hash {
+			return i
+		}
+	}
+	return -1
+}
+
+// maxEdgeLane 返回连线涉及的最大列号
+func maxEdgeLane(edges []models.GitGraphEdge) int {
+	result := -1
+	for _, edge := range edges {
+		result = max(result, edge.From, edge.To)
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-git-client-window/models"
+)
+
+func TestLayoutCommitGraph(t *testing.T) {
+	commit := func(hash string, parents ...string) graphCommit {
+		return graphCommit{record: models.GitCommitRecord{Hash: hash}, parents: parents}
+	}
+
+	// M 合并 B 与 D；B、D 分叉自 A；E 为另一条从 A 分出的分支
+	rows := layoutCommitGraph([]graphCommit{
+		commit("M", "B", "D"),
+		commit("E", "A"),
+		commit("D", "C"),
+		commit("B", "A"),
+		commit("C", "A"),
+		commit("A"),
+	})
+	require.Len(t, rows, 6)
+
+	lanes := []int{}
+	for _, row := range rows {
+		lanes = append(lanes, row.Lane)
+	}
+	assert.Equal(t, []int{0, 2, 1, 0, 1, 0}, lanes)
+
+	assert.True(t, rows[0].IsMerge)
+	assert.Empty(t, rows[0].EdgesIn)
+	assert.Equal(t, []models.GitGraphEdge{{From: 0, To: 0}, {From: 0, To: 1}}, rows[0].EdgesOut)
+
+	assert.Equal(t, []models.GitGraphEdge{{From: 0, To: 0}, {From: 1, To: 1}}, rows[1].EdgesIn)
+	assert.Equal(t, 3, rows[1].Width)
+
+	// A 汇合三条列
+	assert.Equal(t, []models.GitGraphEdge{{From: 0, To: 0}, {From: 1, To: 0}, {From: 2, To: 0}}, rows[5].EdgesIn)
+	assert.Empty(t, rows[5].EdgesOut)
+	assert.Equal(t, 3, rows[5].Width)
+}
+
+func TestGetCommitGraph(t *testing.T) {
+	dir := newConflictRepo(t)
+	mustGit(t, dir, "merge", "-X", "theirs", "--no-edit", "feature")
+	mustGit(t, dir, "tag", "v1")
+
+	page, err := gitService.GetCommitGraph(dir, models.GitGraphOptions{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page.Rows, 2)
+	assert.True(t, page.HasMore)
+	assert.True(t, page.Rows[0].IsMerge)
+	assert.Equal(t, []string{"v1"}, page.Rows[0].Commit.Tags)
+	assert.Len(t, page.Rows[0].Parents, 2)
+
+	next, err := gitService.GetCommitGraph(dir, models.GitGraphOptions{Skip: 2, Limit: 10})
+	require.NoError(t, err)
+	assert.False(t, next.HasMore)
+	require.Len(t, next.Rows, 3)
+	assert.Equal(t, "Initial commit", next.Rows[2].Commit.Message)
+	assert.Equal(t, 0, next.Rows[2].Lane)
+
+	_, err = gitService.GetCommitGraph(dir, models.GitGraphOptions{Skip: -1})
+	assert.Error(t, err)
+
+	// 以 - 开头的引用不能被当作选项传给 git
+	output := filepath.Join(t.TempDir(), "out")
+	_, err = gitService.GetCommitGraph(dir, models.GitGraphOptions{Refs: []string{"--output=" + output}})
+	assert.Error(t, err)
+	assert.NoFileExists(t, output)
+}
@@ -0,0 +1,204 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go-git-client-window/models"
+)
+
+// DefaultLogPageSize 提交日志默认每页提交数
+const DefaultLogPageSize = 100
+
+// logFormat 完整提交记录格式，记录间以 NUL 分隔（-z），提交信息放在最后
+const logFormat = "%H%x1f%P%x1f%an%x1f%ae%x1f%aI%x1f%cn%x1f%ce%x1f%cI%x1f%D%x1f%s%x1f%b%x1f%B"
+
+// logFieldCount logFormat 中的字段数
+const logFieldCount = 12
+
+// QueryLog 按条件分页查询提交日志
+func (s *GitCoreService) QueryLog(repoPath string, query models.GitLogQuery) (*models.GitLogPage, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+
+	filters, err := logFilterArgs(query)
+	if err != nil {
+		return nil, err
+	}
+	if query.Limit <= 0 {
+		query.Limit = DefaultLogPageSize
+	}
+
+	skip := query.Skip
+	if query.After != "" {
+		if skip, err = logCursorOffset(repoPath, filters, query.After); err != nil {
+			return nil, err
+		}
+	}
+
+	// 多读一条用于判断是否还有下一页
+	args := []string{"log", "-z", "--format=" + logFormat, "--skip=" + strconv.Itoa(skip), "-n", strconv.Itoa(query.Limit + 1)}
+	result, err := runGit(repoPath, append(args, filters...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := ParseLogRecords(result.Stdout)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.GitLogPage{Commits: commits}
+	if len(commits) > query.Limit {
+		page.HasMore = true
+		page.Commits = commits[:query.Limit]
+	}
+	if len(page.Commits) > 0 {
+		page.NextCursor = page.Commits[len(page.Commits)-1].Hash
+	}
+
+	return page, nil
+}
+
+// logFilterArgs 将查询条件转换为 git log 参数（含末尾的引用与路径）
+func logFilterArgs(query models.GitLogQuery) ([]string, error) {
+	if query.Skip < 0 {
+		return nil, fmt.Errorf("skip cannot be negative")
+	}
+	if query.MergesOnly && query.NoMerges {
+		return nil, fmt.Errorf("mergesOnly and noMerges cannot both be set")
+	}
+	if query.Follow && strings.TrimSpace(query.Path) == "" {
+		return nil, fmt.Errorf("follow requires a path")
+	}
+
+	var args []string
+	if query.Author != "" {
+		args = append(args, "--author="+query.Author)
+	}
+	if query.Committer != "" {
+		args = append(args, "--committer="+query.Committer)
+	}
+	if query.Since != "" {
+		args = append(args, "--since="+query.Since)
+	}
+	if query.Until != "" {
+		args = append(args, "--until="+query.Until)
+	}
+	if query.Grep != "" {
+		args = append(args, "--grep="+query.Grep)
+	}
+	if query.IgnoreCase {
+		args = append(args, "--regexp-ignore-case")
+	}
+	if query.Pickaxe != "" {
+		if query.PickaxeRegex {
+			args = append(args, "-G"+query.Pickaxe)
+		} else {
+			args = append(args, "-S"+query.Pickaxe)
+		}
+	}
+	if query.MergesOnly {
+		args = append(args, "--merges")
+	}
+	if query.NoMerges {
+		args = append(args, "--no-merges")
+	}
+	if query.FirstParent {
+		args = append(args, "--first-parent")
+	}
+	if query.Follow {
+		args = append(args, "--follow")
+	}
+	if query.All {
+		args = append(args, "--all")
+	}
+	if err := checkRevisionArgs(query.Refs); err != nil {
+		return nil, err
+	}
+	args = append(args, query.Refs...)
+	args = append(args, "--")
+	if query.Path != "" {
+		args = append(args, query.Path)
+	}
+
+	return args, nil
+}
+
+// logCursorOffset 计算游标提交在相同条件的日志中的位置，返回其后一条的偏移量
+// 游标先解析为完整哈希，避免短哈希歧义；逐行读取日志，找到游标后立即结束 git 进程
+func logCursorOffset(repoPath string, filters []string, after string) (int, error) {
+	if err := checkRevisionArgs([]string{after}); err != nil {
+		return 0, err
+	}
+	resolved, err := runGit(repoPath, "rev-parse", "--verify", "--quiet", after+"^{commit}")
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor commit: %s", after)
+	}
+	cursor := strings.TrimSpace(resolved.Stdout)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	offset, found := 0, false
+	_, err = RunGitCommand(ctx, GitCommandOptions{
+		Dir:  repoPath,
+		Args: append([]string{"log", "--format=%H"}, filters...),
+		Stdout: &lineWriter{onLine: func(hash string) {
+			if found {
+				return
+			}
+			offset++
+			if hash == cursor {
+				found = true
+				cancel()
+			}
+		}},
+		StreamStdout: true,
+	})
+	if found {
+		return offset, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return 0, fmt.Errorf("cursor commit not found in log: %s", after)
+}
+
+// ParseLogRecords 解析 logFormat 格式、以 NUL 分隔的提交记录
+func ParseLogRecords(output string) ([]models.GitLogEntry, error) {
+	commits := []models.GitLogEntry{}
+	for _, record := range strings.Split(output, "\x00") {
+		record = strings.TrimPrefix(record, "\n")
+		if record == "" {
+			continue
+		}
+
+		parts := strings.SplitN(record, "\x1f", logFieldCount)
+		if len(parts) < logFieldCount {
+			return nil, fmt.Errorf("invalid log record format: %s", record)
+		}
+
+		branches, tags := ParseRefNames(parts[8])
+		commits = append(commits, models.GitLogEntry{
+			Hash:           parts[0],
+			Parents:        strings.Fields(parts[1]),
+			AuthorName:     parts[2],
+			AuthorEmail:    parts[3],
+			AuthorDate:     parts[4],
+			CommitterName:  parts[5],
+			CommitterEmail: parts[6],
+			CommitterDate:  parts[7],
+			Subject:        parts[9],
+			Body:           strings.TrimRight(parts[10], "\n"),
+			Message:        strings.TrimRight(parts[11], "\n"),
+			Branches:       branches,
+			Tags:           tags,
+		})
+	}
+
+	return commits, nil
+}
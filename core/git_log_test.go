@@ -0,0 +1,92 @@
+package core
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-git-client-window/models"
+)
+
+func TestQueryLog(t *testing.T) {
+	dir := newConflictRepo(t)
+	mustGit(t, dir, "merge", "-X", "theirs", "--no-edit", "feature")
+	writeTestFile(t, dir, "notes.txt", "unique notes\n")
+	mustGit(t, dir, "add", "notes.txt")
+	mustGit(t, dir, "commit", "-m", "add notes")
+	mustGit(t, dir, "mv", "notes.txt", "renamed.txt")
+	mustGit(t, dir, "commit", "-m", "rename feature\n\nmove the file\nsecond line", "--author", "Other <other@example.com>")
+
+	subjects := func(page *models.GitLogPage) []string {
+		var result []string
+		for _, commit := range page.Commits {
+			result = append(result, commit.Subject)
+		}
+		return result
+	}
+
+	page, err := gitService.QueryLog(dir, models.GitLogQuery{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page.Commits, 2)
+	assert.True(t, page.HasMore)
+	head := page.Commits[0]
+	assert.Equal(t, "rename feature", head.Subject)
+	assert.Equal(t, "move the file\nsecond line", head.Body)
+	assert.Equal(t, "rename feature\n\nmove the file\nsecond line", head.Message)
+	assert.Equal(t, "Other", head.AuthorName)
+	assert.Equal(t, "other@example.com", head.AuthorEmail)
+	assert.NotEmpty(t, head.CommitterName)
+	assert.NotEmpty(t, head.CommitterDate)
+	assert.Equal(t, []string{"HEAD -> master"}, head.Branches)
+	assert.Len(t, page.Commits[1].Parents, 1)
+
+	next, err := gitService.QueryLog(dir, models.GitLogQuery{After: page.NextCursor, Limit: 10})
+	require.NoError(t, err)
+	assert.False(t, next.HasMore)
+	assert.ElementsMatch(t, []string{"Merge branch 'feature'", "master readme", "feature file", "feature readme", "Initial commit"}, subjects(next))
+
+	cases := []struct {
+		name  string
+		query models.GitLogQuery
+		want  []string
+	}{
+		{"author", models.GitLogQuery{Author: "other@"}, []string{"rename feature"}},
+		{"grep", models.GitLogQuery{Grep: "README", IgnoreCase: true}, []string{"master readme", "feature readme"}},
+		{"pickaxe", models.GitLogQuery{Pickaxe: "master"}, []string{"master readme"}},
+		{"merges", models.GitLogQuery{MergesOnly: true}, []string{"Merge branch 'feature'"}},
+		{"pickaxe-regex", models.GitLogQuery{Pickaxe: "^unique", PickaxeRegex: true}, []string{"add notes"}},
+		{"first-parent", models.GitLogQuery{FirstParent: true, NoMerges: true}, []string{"rename feature", "add notes", "master readme", "Initial commit"}},
+		{"follow", models.GitLogQuery{Path: "renamed.txt", Follow: true}, []string{"rename feature", "add notes"}},
+		{"refs", models.GitLogQuery{Refs: []string{"feature"}, Path: "feature.txt"}, []string{"feature file"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			page, err := gitService.QueryLog(dir, c.query)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, c.want, subjects(page))
+		})
+	}
+
+	// 短哈希游标先解析为完整哈希
+	short, err := gitService.QueryLog(dir, models.GitLogQuery{After: page.NextCursor[:12], Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, subjects(next), subjects(short))
+
+	_, err = gitService.QueryLog(dir, models.GitLogQuery{After: "0000000"})
+	assert.Error(t, err)
+	// 游标不在过滤后的日志中
+	_, err = gitService.QueryLog(dir, models.GitLogQuery{After: page.NextCursor, Author: "nobody"})
+	assert.Error(t, err)
+	output := filepath.Join(t.TempDir(), "out")
+	_, err = gitService.QueryLog(dir, models.GitLogQuery{Refs: []string{"--output=" + output}})
+	assert.Error(t, err)
+	assert.NoFileExists(t, output)
+	_, err = gitService.QueryLog(dir, models.GitLogQuery{After: "--output=" + output})
+	assert.Error(t, err)
+	_, err = gitService.QueryLog(dir, models.GitLogQuery{Follow: true})
+	assert.Error(t, err)
+	_, err = gitService.QueryLog(dir, models.GitLogQuery{MergesOnly: true, NoMerges: true})
+	assert.Error(t, err)
+}
@@ -0,0 +1,154 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go-git-client-window/models"
+)
+
+// MergeWithOptions 按指定选项合并分支
+func (s *GitCoreService) MergeWithOptions(repoPath string, opts models.GitMergeOptions) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(opts.Branch) == "" {
+		return "", fmt.Errorf("branch cannot be empty")
+	}
+	if err := checkRevisionArgs([]string{opts.Branch}); err != nil {
+		return "", err
+	}
+
+	args := []string{"merge"}
+	switch opts.FastForward {
+	case models.MergeFFDefault:
+	case models.MergeFFNever:
+		args = append(args, "--no-ff")
+	case models.MergeFFOnly:
+		args = append(args, "--ff-only")
+	default:
+		return "", fmt.Errorf("unsupported fast-forward mode: %s", opts.FastForward)
+	}
+	if opts.Squash {
+		args = append(args, "--squash")
+	}
+	if opts.NoCommit {
+		args = append(args, "--no-commit")
+	}
+	if opts.Strategy != "" {
+		args = append(args, "--strategy", opts.Strategy)
+	}
+	for _, option := range opts.StrategyOptions {
+		args = append(args, "--strategy-option", option)
+	}
+	if opts.Message != "" {
+		args = append(args, "--message", opts.Message)
+	} else {
+		// 不打开编辑器，使用默认合并信息
+		args = append(args, "--no-edit")
+	}
+	args = append(args, opts.Branch)
+
+	output, err := ExecuteGitCommand(repoPath, args...)
+	if err != nil {
+		return "", err
+	}
+
+	return output, nil
+}
+
+// PreviewMerge 预演合并：判断是否已是最新、能否快进，并通过 merge-tree 检查冲突，不修改工作区
+// merge-tree 使用默认策略，-s/-X 选项不参与预演
+func (s *GitCoreService) PreviewMerge(repoPath, branch string) (*models.GitMergePreview, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+	if err := checkRevisionArgs([]string{branch}); err != nil {
+		return nil, err
+	}
+
+	preview := &models.GitMergePreview{Branch: branch, ConflictFiles: []string{}}
+
+	head, err := runGit(repoPath, "rev-parse", "--verify", "HEAD^{commit}")
+	if err != nil {
+		return nil, err
+	}
+	preview.Head = strings.TrimSpace(head.Stdout)
+
+	target, err := runGit(repoPath, "rev-parse", "--verify", branch+"^{commit}")
+	if err != nil {
+		return nil, err
+	}
+	preview.Target = strings.TrimSpace(target.Stdout)
+
+	// 无共同祖先时 merge-base 以退出码 1 结束，此时交给 merge-tree 判断，其余失败需要返回
+	base, err := runGit(repoPath, "merge-base", preview.Head, preview.Target)
+	switch {
+	case err == nil:
+		preview.MergeBase = strings.TrimSpace(base.Stdout)
+	case base == nil || base.ExitCode != 1:
+		return nil, err
+	}
+
+	switch preview.MergeBase {
+	case preview.Target:
+		preview.AlreadyUpToDate = true
+		return preview, nil
+	case preview.Head:
+		preview.FastForward = true
+		return preview, nil
+	}
+
+	// 退出码 0 表示无冲突，1 表示有冲突，其他为错误
+	result, err := RunGitCommand(context.Background(), GitCommandOptions{
+		Dir:  repoPath,
+		Args: []string{"merge-tree", "--write-tree", "--name-only", "--no-messages", "-z", preview.Head, preview.Target},
+	})
+	if err != nil && result.ExitCode != 1 {
+		return nil, err
+	}
+
+	fields := strings.Split(result.Stdout, "\x00")
+	preview.Tree = strings.TrimSpace(fields[0])
+	for _, file := range fields[1:] {
+		if file != "" {
+			preview.ConflictFiles = append(preview.ConflictFiles, file)
+		}
+	}
+	preview.Conflicts = result.ExitCode == 1
+
+	return preview, nil
+}
+
+// MergeAbort 放弃进行中的合并
+func (s *GitCoreService) MergeAbort(repoPath string) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+
+	output, err := ExecuteGitCommand(repoPath, "merge", "--abort")
+	if err != nil {
+		return "", err
+	}
+
+	return output, nil
+}
+
+// MergeContinue 解决冲突后完成合并，使用 git 准备好的合并信息
+func (s *GitCoreService) MergeContinue(repoPath string) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+
+	result, err := RunGitCommand(context.Background(), GitCommandOptions{
+		Dir:  repoPath,
+		Args: []string{"merge", "--continue"},
+		Env:  []string{"GIT_EDITOR=true"},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return result.CombinedOutput(), nil
+}
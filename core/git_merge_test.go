@@ -0,0 +1,116 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-git-client-window/models"
+)
+
+func TestPreviewMerge(t *testing.T) {
+	dir := newConflictRepo(t)
+	mustGit(t, dir, "branch", "behind", "master~1")
+	mustGit(t, dir, "branch", "ahead", "master")
+	mustGit(t, dir, "checkout", "-b", "clean", "master~1")
+	writeTestFile(t, dir, "clean.txt", "clean\n")
+	mustGit(t, dir, "add", "clean.txt")
+	mustGit(t, dir, "commit", "-m", "clean change")
+	mustGit(t, dir, "checkout", "master")
+
+	preview, err := gitService.PreviewMerge(dir, "feature")
+	require.NoError(t, err)
+	assert.True(t, preview.Conflicts)
+	assert.Equal(t, []string{"README.md"}, preview.ConflictFiles)
+	assert.NotEmpty(t, preview.MergeBase)
+
+	preview, err = gitService.PreviewMerge(dir, "clean")
+	require.NoError(t, err)
+	assert.False(t, preview.Conflicts)
+	assert.False(t, preview.FastForward)
+	assert.NotEmpty(t, preview.Tree)
+
+	preview, err = gitService.PreviewMerge(dir, "behind")
+	require.NoError(t, err)
+	assert.True(t, preview.AlreadyUpToDate)
+
+	mustGit(t, dir, "checkout", "behind")
+	preview, err = gitService.PreviewMerge(dir, "ahead")
+	require.NoError(t, err)
+	assert.True(t, preview.FastForward)
+
+	_, err = gitService.PreviewMerge(dir, "no-such-branch")
+	assert.True(t, IsGitErrorKind(err, ErrKindUnknownRevision))
+	_, err = gitService.PreviewMerge(dir, "--output=x")
+	assert.ErrorContains(t, err, "invalid revision")
+
+	// 无共同祖先时 merge-base 退出码为 1，仍应交给 merge-tree 判断（merge-tree 同 merge 一样拒绝无关历史）
+	mustGit(t, dir, "checkout", "--orphan", "unrelated")
+	mustGit(t, dir, "rm", "-rf", "--quiet", ".")
+	writeTestFile(t, dir, "other.txt", "other\n")
+	mustGit(t, dir, "add", "other.txt")
+	mustGit(t, dir, "commit", "-m", "unrelated root")
+	mustGit(t, dir, "checkout", "master")
+	_, err = gitService.PreviewMerge(dir, "unrelated")
+	require.Error(t, err)
+	assert.Contains(t, AsGitError(err).Command, "merge-tree")
+
+	// 预演不应修改工作区
+	status, err := gitService.GetRepoStatus(dir)
+	require.NoError(t, err)
+	assert.Empty(t, status.Files)
+}
+
+func TestMergeWithOptions(t *testing.T) {
+	t.Run("ff-only 拒绝非快进合并", func(t *testing.T) {
+		dir := newConflictRepo(t)
+		_, err := gitService.MergeWithOptions(dir, models.GitMergeOptions{Branch: "feature", FastForward: models.MergeFFOnly})
+		assert.Error(t, err)
+	})
+
+	t.Run("-X theirs 自动解决冲突", func(t *testing.T) {
+		dir := newConflictRepo(t)
+		_, err := gitService.MergeWithOptions(dir, models.GitMergeOptions{
+			Branch:          "feature",
+			StrategyOptions: []string{"theirs"},
+			Message:         "custom merge",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "feature\n", mustGit(t, dir, "show", "HEAD:README.md"))
+		assert.Equal(t, "custom merge\n", mustGit(t, dir, "log", "-1", "--format=%s"))
+	})
+
+	t.Run("squash 只写入暂存区", func(t *testing.T) {
+		dir := newConflictRepo(t)
+		mustGit(t, dir, "checkout", "-b", "base", "master~1")
+		_, err := gitService.MergeWithOptions(dir, models.GitMergeOptions{Branch: "feature", Squash: true})
+		require.NoError(t, err)
+		assert.Equal(t, "Initial commit\n", mustGit(t, dir, "log", "-1", "--format=%s"))
+		assert.Contains(t, mustGit(t, dir, "diff", "--cached", "--name-only"), "feature.txt")
+	})
+
+	t.Run("冲突后继续与放弃", func(t *testing.T) {
+		dir := newConflictRepo(t)
+		_, err := gitService.MergeWithOptions(dir, models.GitMergeOptions{Branch: "feature", FastForward: models.MergeFFNever})
+		assert.True(t, IsGitErrorKind(err, ErrKindConflict))
+
+		_, err = gitService.MergeContinue(dir)
+		assert.Error(t, err)
+
+		writeTestFile(t, dir, "README.md", "resolved\n")
+		mustGit(t, dir, "add", "README.md")
+		_, err = gitService.MergeContinue(dir)
+		require.NoError(t, err)
+		assert.Contains(t, mustGit(t, dir, "log", "-1", "--format=%s"), "Merge branch 'feature'")
+
+		mustGit(t, dir, "reset", "--hard", "HEAD~1")
+		_, err = gitService.Merge(dir, "feature")
+		require.Error(t, err)
+		_, err = gitService.MergeAbort(dir)
+		require.NoError(t, err)
+		state, err := gitService.GetRepoState(dir)
+		require.NoError(t, err)
+		assert.Equal(t, models.RepoOperationNone, state.Operation)
+	})
+}
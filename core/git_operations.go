@@ -0,0 +1,86 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// operationEntry 正在执行的操作
+type operationEntry struct {
+	cancel context.CancelFunc
+}
+
+// OperationRegistry 记录正在执行的可取消操作，供前端按操作 ID 取消
+type OperationRegistry struct {
+	mu         sync.Mutex
+	operations map[string]*operationEntry
+}
+
+// NewOperationRegistry 创建操作注册表
+func NewOperationRegistry() *OperationRegistry {
+	return &OperationRegistry{operations: make(map[string]*operationEntry)}
+}
+
+// Begin 注册一个操作并返回其上下文，timeout 为 0 时不限时
+// 调用方必须在操作结束后调用返回的 done 释放资源
+func (r *OperationRegistry) Begin(parent context.Context, id string, timeout time.Duration) (context.Context, func()) {
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(parent, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(parent)
+	}
+	if id == "" {
+		return ctx, cancel
+	}
+
+	entry := &operationEntry{cancel: cancel}
+	r.mu.Lock()
+	if previous, ok := r.operations[id]; ok {
+		previous.cancel()
+	}
+	r.operations[id] = entry
+	r.mu.Unlock()
+
+	return ctx, func() {
+		r.mu.Lock()
+		if r.operations[id] == entry {
+			delete(r.operations, id)
+		}
+		r.mu.Unlock()
+		cancel()
+	}
+}
+
+// Cancel 取消指定 ID 的操作
+func (r *OperationRegistry) Cancel(id string) error {
+	r.mu.Lock()
+	entry, ok := r.operations[id]
+	r.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("operation %q is not running", id)
+	}
+	entry.cancel()
+	return nil
+}
+
+// Running 返回正在执行的操作 ID 列表
+func (r *OperationRegistry) Running() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make([]string, 0, len(r.operations))
+	for id := range r.operations {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
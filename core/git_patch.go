@@ -0,0 +1,250 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go-git-client-window/models"
+)
+
+// StageSelection 将工作区差异中选中的块或行加入暂存区
+func (s *GitCoreService) StageSelection(repoPath string, selection models.DiffSelection) (string, error) {
+	return s.applySelection(repoPath, selection, false, "--cached")
+}
+
+// UnstageSelection 将暂存区差异中选中的块或行移出暂存区
+func (s *GitCoreService) UnstageSelection(repoPath string, selection models.DiffSelection) (string, error) {
+	return s.applySelection(repoPath, selection, true, "--cached", "--reverse")
+}
+
+// DiscardSelection 丢弃工作区中选中的块或行（不可恢复）
+func (s *GitCoreService) DiscardSelection(repoPath string, selection models.DiffSelection) (string, error) {
+	return s.applySelection(repoPath, selection, false, "--reverse")
+}
+
+// applySelection 重新计算差异，按选择生成补丁并通过 git apply 应用
+// fromIndex 为 true 时基于暂存区差异（diff --cached），否则基于工作区差异
+func (s *GitCoreService) applySelection(repoPath string, selection models.DiffSelection, fromIndex bool, applyArgs ...string) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(selection.Filename) == "" {
+		return "", fmt.Errorf("filename cannot be empty")
+	}
+
+	diffs, err := s.GetFileDiff(repoPath, selection.Filename, fromIndex)
+	if err != nil {
+		return "", err
+	}
+	if len(diffs) != 1 {
+		return "", fmt.Errorf("no changes found for %s", selection.Filename)
+	}
+
+	reverse := false
+	for _, arg := range applyArgs {
+		if arg == "--reverse" {
+			reverse = true
+		}
+	}
+	patch, err := BuildPatch(diffs[0], selection, reverse)
+	if err != nil {
+		return "", err
+	}
+
+	args := append([]string{"apply", "--whitespace=nowarn"}, applyArgs...)
+	args = append(args, "-")
+	result, err := RunGitCommand(context.Background(), GitCommandOptions{
+		Dir:   repoPath,
+		Args:  args,
+		Stdin: strings.NewReader(patch),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return result.CombinedOutput(), nil
+}
+
+// BuildPatch 根据选中的块或行生成可被 git apply 接受的补丁
+// reverse 表示补丁将以 --reverse 方式应用：此时未选中的新增行保留为上下文、未选中的删除行被丢弃；
+// 正向应用时则相反，未选中的删除行保留为上下文、未选中的新增行被丢弃
+func BuildPatch(file models.FileDiff, selection models.DiffSelection, reverse bool) (string, error) {
+	if file.Binary {
+		return "", fmt.Errorf("cannot partially apply binary file %s", file.Filename)
+	}
+
+	selected, err := selectedLines(file, selection)
+	if err != nil {
+		return "", err
+	}
+
+	var body strings.Builder
+	complete := true
+	// origDelta 为原差异中此前各块的行数变化，delta 为补丁中实际输出的各块的行数变化
+	origDelta, delta := 0, 0
+	for h, hunk := range file.Hunks {
+		lines, oldCount, newCount, changed, hunkComplete := filterHunkLines(hunk, selected[h], reverse)
+		if !hunkComplete {
+			complete = false
+		}
+		if !changed {
+			origDelta += hunk.NewLines - hunk.OldLines
+			continue
+		}
+
+		// 以补丁应用的一侧为准，另一侧起始行按已输出块的行数变化重新推算
+		oldStart, newStart := hunk.OldStart, hunk.NewStart
+		if reverse {
+			oldStart = hunk.OldStart + origDelta - delta
+		} else {
+			newStart = hunk.NewStart - origDelta + delta
+		}
+		oldStart = adjustRangeStart(oldStart, hunk.OldLines, oldCount)
+		newStart = adjustRangeStart(newStart, hunk.NewLines, newCount)
+		origDelta += hunk.NewLines - hunk.OldLines
+		delta += newCount - oldCount
+
+		fmt.Fprintf(&body, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+		body.WriteString(lines)
+	}
+	if body.Len() == 0 {
+		return "", fmt.Errorf("no lines selected")
+	}
+
+	oldPath, newPath := file.OldPath, file.NewPath
+	if oldPath == "" {
+		oldPath = newPath
+	}
+	if newPath == "" {
+		newPath = oldPath
+	}
+
+	var patch strings.Builder
+	fmt.Fprintf(&patch, "diff --git %s %s\n", quotePatchPath("a/"+oldPath), quotePatchPath("b/"+newPath))
+	// 只有整个文件的改动都被选中时才保留新增/删除文件的语义，否则按普通修改处理
+	switch {
+	case file.Status == "A" && complete:
+		fmt.Fprintf(&patch, "new file mode %s\n--- /dev/null\n", file.NewMode)
+	case file.Status == "D" && complete:
+		fmt.Fprintf(&patch, "deleted file mode %s\n", file.OldMode)
+		fmt.Fprintf(&patch, "--- %s\n", quotePatchPath("a/"+oldPath))
+	default:
+		fmt.Fprintf(&patch, "--- %s\n", quotePatchPath("a/"+oldPath))
+	}
+	if file.Status == "D" && complete {
+		patch.WriteString("+++ /dev/null\n")
+	} else {
+		fmt.Fprintf(&patch, "+++ %s\n", quotePatchPath("b/"+newPath))
+	}
+	patch.WriteString(body.String())
+
+	return patch.String(), nil
+}
+
+// selectedLines 将选择展开为 块下标 -> 行下标集合
+func selectedLines(file models.FileDiff, selection models.DiffSelection) (map[int]map[int]bool, error) {
+	selected := make(map[int]map[int]bool)
+	mark := func(h, l int) {
+		if selected[h] == nil {
+			selected[h] = make(map[int]bool)
+		}
+		selected[h][l] = true
+	}
+
+	for _, h := range selection.Hunks {
+		if h < 0 || h >= len(file.Hunks) {
+			return nil, fmt.Errorf("hunk %d out of range", h)
+		}
+		for l := range file.Hunks[h].Lines {
+			mark(h, l)
+		}
+	}
+	for _, ref := range selection.Lines {
+		if ref.Hunk < 0 || ref.Hunk >= len(file.Hunks) {
+			return nil, fmt.Errorf("hunk %d out of range", ref.Hunk)
+		}
+		if ref.Line < 0 || ref.Line >= len(file.Hunks[ref.Hunk].Lines) {
+			return nil, fmt.Errorf("line %d of hunk %d out of range", ref.Line, ref.Hunk)
+		}
+		mark(ref.Hunk, ref.Line)
+	}
+
+	return selected, nil
+}
+
+// filterHunkLines 按选择生成块内容，返回内容、新旧行数、是否包含改动以及改动是否全部选中
+func filterHunkLines(hunk models.DiffHunk, selected map[int]bool, reverse bool) (string, int, int, bool, bool) {
+	var builder strings.Builder
+	oldCount, newCount := 0, 0
+	changed, complete := false, true
+
+	write := func(prefix byte, line models.DiffLine) {
+		builder.WriteByte(prefix)
+		builder.WriteString(line.Content)
+		builder.WriteByte('\n')
+		if line.NoNewline {
+			builder.WriteString("\\ No newline at end of file\n")
+		}
+	}
+
+	for l, line := range hunk.Lines {
+		switch line.Kind {
+		case models.DiffLineContext:
+			write(' ', line)
+			oldCount++
+			newCount++
+		case models.DiffLineAdd:
+			switch {
+			case selected[l]:
+				write('+', line)
+				newCount++
+				changed = true
+			case reverse:
+				// 该行在目标中仍然存在，保留为上下文
+				write(' ', line)
+				oldCount++
+				newCount++
+				complete = false
+			default:
+				complete = false
+			}
+		case models.DiffLineDelete:
+			switch {
+			case selected[l]:
+				write('-', line)
+				oldCount++
+				changed = true
+			case !reverse:
+				write(' ', line)
+				oldCount++
+				newCount++
+				complete = false
+			default:
+				complete = false
+			}
+		}
+	}
+
+	return builder.String(), oldCount, newCount, changed, complete
+}
+
+// adjustRangeStart 修正起始行：空范围的起始行指向其前一行，因此范围在空与非空之间变化时需要加减一
+func adjustRangeStart(start, originalCount, count int) int {
+	switch {
+	case originalCount > 0 && count == 0:
+		return start - 1
+	case originalCount == 0 && count > 0:
+		return start + 1
+	}
+	return start
+}
+
+// quotePatchPath 对包含特殊字符的路径使用 C 风格引号
+func quotePatchPath(path string) string {
+	if strings.ContainsAny(path, "\"\\\t\n") {
+		return strconv.Quote(path)
+	}
+	return path
+}
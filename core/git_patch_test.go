@@ -0,0 +1,123 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-git-client-window/models"
+)
+
+// numberedLines 生成 line1..lineN，每行一个
+func numberedLines(n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line%d", i+1)
+	}
+	return lines
+}
+
+// newPatchTestRepo 创建包含 20 行文件的仓库，并在文件首尾各做一处修改，形成两个差异块
+func newPatchTestRepo(t *testing.T) string {
+	t.Helper()
+	dir := newTestRepo(t)
+	lines := numberedLines(20)
+	writeTestFile(t, dir, "file.txt", strings.Join(lines, "\n")+"\n")
+	mustGit(t, dir, "add", "file.txt")
+	mustGit(t, dir, "commit", "-m", "add file")
+
+	lines[1] = "line2 changed"
+	lines = append(lines[:19], "line20", "line21 added")
+	writeTestFile(t, dir, "file.txt", strings.Join(lines, "\n")+"\n")
+	return dir
+}
+
+func TestStageSelectionHunk(t *testing.T) {
+	dir := newPatchTestRepo(t)
+
+	_, err := gitService.StageSelection(dir, models.DiffSelection{Filename: "file.txt", Hunks: []int{1}})
+	require.NoError(t, err)
+
+	staged, err := gitService.GetFileDiff(dir, "file.txt", true)
+	require.NoError(t, err)
+	require.Len(t, staged, 1)
+	require.Len(t, staged[0].Hunks, 1)
+	assert.Equal(t, 1, staged[0].Additions)
+	assert.Equal(t, 0, staged[0].Deletions)
+
+	unstaged, err := gitService.GetFileDiff(dir, "file.txt", false)
+	require.NoError(t, err)
+	require.Len(t, unstaged[0].Hunks, 1)
+	assert.Equal(t, 1, unstaged[0].Deletions)
+}
+
+func TestStageSelectionLines(t *testing.T) {
+	dir := newPatchTestRepo(t)
+
+	diffs, err := gitService.GetFileDiff(dir, "file.txt", false)
+	require.NoError(t, err)
+	// 第一个块中只暂存新增行，不暂存删除行
+	var addIndex int
+	for i, line := range diffs[0].Hunks[0].Lines {
+		if line.Kind == models.DiffLineAdd {
+			addIndex = i
+		}
+	}
+
+	_, err = gitService.StageSelection(dir, models.DiffSelection{
+		Filename: "file.txt",
+		Lines:    []models.DiffLineRef{{Hunk: 0, Line: addIndex}},
+	})
+	require.NoError(t, err)
+
+	index := mustGit(t, dir, "show", ":file.txt")
+	assert.Contains(t, index, "line2\nline2 changed\nline3")
+	assert.NotContains(t, index, "line21 added")
+
+	_, err = gitService.UnstageSelection(dir, models.DiffSelection{Filename: "file.txt", Hunks: []int{0}})
+	require.NoError(t, err)
+	staged, err := gitService.GetFileDiff(dir, "file.txt", true)
+	require.NoError(t, err)
+	assert.Empty(t, staged)
+}
+
+func TestDiscardSelection(t *testing.T) {
+	dir := newPatchTestRepo(t)
+
+	_, err := gitService.DiscardSelection(dir, models.DiffSelection{Filename: "file.txt", Hunks: []int{0}})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "file.txt"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "line1\nline2\nline3")
+	assert.Contains(t, string(content), "line21 added")
+}
+
+func TestBuildPatchPartialNewFile(t *testing.T) {
+	file := models.FileDiff{
+		Filename: "new.txt",
+		NewPath:  "new.txt",
+		Status:   "A",
+		NewMode:  "100644",
+		Hunks: []models.DiffHunk{{
+			OldStart: 0, OldLines: 0, NewStart: 1, NewLines: 3,
+			Lines: []models.DiffLine{
+				{Kind: models.DiffLineAdd, Content: "a", NewLine: 1},
+				{Kind: models.DiffLineAdd, Content: "b", NewLine: 2},
+				{Kind: models.DiffLineAdd, Content: "c", NewLine: 3},
+			},
+		}},
+	}
+
+	patch, err := BuildPatch(file, models.DiffSelection{Lines: []models.DiffLineRef{{Hunk: 0, Line: 1}}}, true)
+	require.NoError(t, err)
+	assert.Equal(t, "diff --git a/new.txt b/new.txt\n--- a/new.txt\n+++ b/new.txt\n@@ -1,2 +1,3 @@\n a\n+b\n c\n", patch)
+
+	_, err = BuildPatch(file, models.DiffSelection{}, false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,34 @@
+//go:build windows
+
+package core
+
+import (
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+// createNoWindow 避免在 GUI 进程中为 git 弹出控制台窗口
+const createNoWindow = 0x08000000
+
+// configureProcessGroup 让 git 进程运行在独立的进程组中，便于整体终止
+func configureProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP | createNoWindow,
+		HideWindow:    true,
+	}
+}
+
+// killProcessGroup 终止 git 及其派生的全部子进程
+func killProcessGroup(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	// taskkill /T 会连同子进程树一起终止
+	kill := exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid))
+	kill.SysProcAttr = &syscall.SysProcAttr{HideWindow: true, CreationFlags: createNoWindow}
+	if err := kill.Run(); err != nil {
+		return cmd.Process.Kill()
+	}
+	return nil
+}
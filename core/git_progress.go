@@ -0,0 +1,127 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go-git-client-window/models"
+)
+
+// ProgressFunc 进度回调
+type ProgressFunc func(progress models.GitProgress)
+
+// progressPhases git 会输出进度的阶段
+var progressPhases = map[string]bool{
+	"Enumerating objects": true,
+	"Counting objects":    true,
+	"Compressing objects": true,
+	"Writing objects":     true,
+	"Receiving objects":   true,
+	"Resolving deltas":    true,
+	"Updating files":      true,
+	"Checking out files":  true,
+	"Finding sources":     true,
+}
+
+var (
+	// 例: "Receiving objects:  45% (450/1000), 1.20 MiB | 500.00 KiB/s"
+	progressPercentPattern = regexp.MustCompile(`^(\d+)%\s+\((\d+)/(\d+)\)`)
+	// 例: "Enumerating objects: 5, done."
+	progressCountPattern = regexp.MustCompile(`^(\d+)`)
+	// 例: ", 1.20 MiB | 500.00 KiB/s"
+	progressTransferPattern = regexp.MustCompile(`([\d.]+\s*[KMGT]?i?B)\s*\|\s*([\d.]+\s*[KMGT]?i?B/s)`)
+)
+
+// ParseProgressLine 解析 git --progress 输出的一行，非进度行返回 false
+func ParseProgressLine(line string) (models.GitProgress, bool) {
+	progress := models.GitProgress{Percent: -1}
+
+	line = strings.TrimSpace(line)
+	if rest, ok := strings.CutPrefix(line, "remote:"); ok {
+		progress.Remote = true
+		line = strings.TrimSpace(rest)
+	}
+
+	phase, rest, ok := strings.Cut(line, ":")
+	if !ok || !progressPhases[phase] {
+		return progress, false
+	}
+	progress.Phase = phase
+	rest = strings.TrimSpace(rest)
+
+	if m := progressPercentPattern.FindStringSubmatch(rest); m != nil {
+		progress.Percent, _ = strconv.Atoi(m[1])
+		progress.Current, _ = strconv.ParseInt(m[2], 10, 64)
+		progress.Total, _ = strconv.ParseInt(m[3], 10, 64)
+	} else if m := progressCountPattern.FindStringSubmatch(rest); m != nil {
+		progress.Current, _ = strconv.ParseInt(m[1], 10, 64)
+	} else {
+		return progress, false
+	}
+
+	if m := progressTransferPattern.FindStringSubmatch(rest); m != nil {
+		progress.Transferred = m[1]
+		progress.Speed = m[2]
+	}
+	progress.Done = strings.HasSuffix(rest, "done.")
+
+	return progress, true
+}
+
+// progressWriter 接收 git 的 stderr，按 \r 或 \n 切分后解析进度
+type progressWriter struct {
+	onProgress ProgressFunc
+	pending    []byte
+}
+
+// newProgressWriter 创建进度解析器
+func newProgressWriter(onProgress ProgressFunc) *progressWriter {
+	return &progressWriter{onProgress: onProgress}
+}
+
+// Write 实现 io.Writer
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.pending = append(w.pending, p...)
+	for {
+		i := bytes.IndexAny(w.pending, "\r\n")
+		if i < 0 {
+			break
+		}
+		w.handleLine(string(w.pending[:i]))
+		w.pending = w.pending[i+1:]
+	}
+	return len(p), nil
+}
+
+// handleLine 解析单行进度并回调
+func (w *progressWriter) handleLine(line string) {
+	if progress, ok := ParseProgressLine(line); ok {
+		w.onProgress(progress)
+	}
+}
+
+// runWithProgress 执行带 --progress 的命令，onProgress 为 nil 时不解析进度
+func runWithProgress(ctx context.Context, dir string, onProgress ProgressFunc, args ...string) (*GitCommandResult, error) {
+	opts := GitCommandOptions{Dir: dir, Args: args}
+	if onProgress != nil {
+		opts.Stderr = newProgressWriter(onProgress)
+	}
+	return RunGitCommand(ctx, opts)
+}
+
+// progressOutput 返回去除进度行后的命令输出
+func progressOutput(result *GitCommandResult) string {
+	var builder strings.Builder
+	builder.WriteString(result.Stdout)
+	for _, line := range strings.FieldsFunc(result.Stderr, func(r rune) bool { return r == '\r' || r == '\n' }) {
+		if _, ok := ParseProgressLine(line); ok {
+			continue
+		}
+		builder.WriteString(line)
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-git-client-window/models"
+)
+
+func TestParseProgressLine(t *testing.T) {
+	tests := []struct {
+		line string
+		ok   bool
+		want models.GitProgress
+	}{
+		{
+			line: "Receiving objects:  45% (450/1000), 1.20 MiB | 500.00 KiB/s",
+			ok:   true,
+			want: models.GitProgress{Phase: "Receiving objects", Percent: 45, Current: 450, Total: 1000, Transferred: "1.20 MiB", Speed: "500.00 KiB/s"},
+		},
+		{
+			line: "remote: Counting objects: 100% (10/10), done.",
+			ok:   true,
+			want: models.GitProgress{Remote: true, Phase: "Counting objects", Percent: 100, Current: 10, Total: 10, Done: true},
+		},
+		{
+			line: "remote: Enumerating objects: 5, done.",
+			ok:   true,
+			want: models.GitProgress{Remote: true, Phase: "Enumerating objects", Percent: -1, Current: 5, Done: true},
+		},
+		{
+			line: "Resolving deltas: 100% (5/5), done.",
+			ok:   true,
+			want: models.GitProgress{Phase: "Resolving deltas", Percent: 100, Current: 5, Total: 5, Done: true},
+		},
+		{line: "From github.com:foo/bar", ok: false},
+		{line: "fatal: repository not found", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, ok := ParseProgressLine(tt.line)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestProgressWriterSplitsCarriageReturns(t *testing.T) {
+	var phases []int
+	writer := newProgressWriter(func(progress models.GitProgress) {
+		phases = append(phases, progress.Percent)
+	})
+
+	_, _ = writer.Write([]byte("Receiving objects:  10% (1/10)\rReceiving obj"))
+	_, _ = writer.Write([]byte("ects:  50% (5/10)\rReceiving objects: 100% (10/10), done.\n"))
+
+	assert.Equal(t, []int{10, 50, 100}, phases)
+}
+
+func TestCloneReportsProgress(t *testing.T) {
+	source := newTestRepo(t)
+	target := filepath.Join(t.TempDir(), "clone")
+
+	var events []models.GitProgress
+	output, err := gitService.Clone(context.Background(), fileURL(source), target, func(progress models.GitProgress) {
+		events = append(events, progress)
+	})
+	require.NoError(t, err)
+	assert.Contains(t, output, "Cloning into")
+	assert.NotContains(t, output, "Receiving objects")
+	assert.NotEmpty(t, events)
+}
+
+// fileURL 将本地路径转换为 file:// 地址，强制 git 走传输协议以输出进度
+func fileURL(path string) string {
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return "file://" + path
+}
@@ -0,0 +1,208 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go-git-client-window/models"
+)
+
+// PullWithOptions 按指定整合方式拉取，并结构化返回结果
+// 未指定远程与分支时从当前分支配置的上游拉取
+func (s *GitCoreService) PullWithOptions(ctx context.Context, repoPath string, opts models.GitPullOptions, onProgress ProgressFunc) (*models.GitPullResult, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+	if err := checkRevisionArgs([]string{opts.Remote, opts.Branch}); err != nil {
+		return nil, err
+	}
+
+	args := []string{"pull", "--progress"}
+	switch opts.Strategy {
+	case models.PullStrategyDefault:
+	case models.PullStrategyMerge:
+		args = append(args, "--no-rebase")
+	case models.PullStrategyRebase:
+		args = append(args, "--rebase")
+	case models.PullStrategyFFOnly:
+		args = append(args, "--ff-only")
+	default:
+		return nil, fmt.Errorf("unsupported pull strategy: %s", opts.Strategy)
+	}
+	if opts.Autostash {
+		args = append(args, "--autostash")
+	}
+	if opts.Prune {
+		args = append(args, "--prune")
+	}
+	if opts.RecurseSubmodules {
+		args = append(args, "--recurse-submodules")
+	}
+	switch {
+	case opts.Branch != "":
+		args = append(args, defaultRemote(opts.Remote), opts.Branch)
+	case opts.Remote != "":
+		args = append(args, opts.Remote)
+	}
+
+	pull := &models.GitPullResult{Remote: opts.Remote, Branch: opts.Branch}
+	if opts.Branch == "" {
+		if err := fillPullUpstream(repoPath, pull); err != nil {
+			return nil, err
+		}
+	} else if opts.Remote == "" {
+		pull.Remote = defaultRemote("")
+	}
+
+	pull.OldHead = revParseOptional(repoPath, "HEAD")
+	before, err := snapshotRefs(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := runWithProgress(ctx, repoPath, onProgress, args...)
+	if err != nil {
+		return nil, err
+	}
+	pull.Output = progressOutput(result)
+
+	after, err := snapshotRefs(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	pull.UpdatedRefs = diffRefSnapshots(before, after)
+	pull.NewHead = revParseOptional(repoPath, "HEAD")
+	pull.Outcome = pullOutcome(repoPath, opts.Strategy, pull.OldHead, pull.NewHead)
+
+	return pull, nil
+}
+
+// fillPullUpstream 未指定分支时记录当前分支的上游，未配置上游时返回 NoUpstream 错误
+func fillPullUpstream(repoPath string, pull *models.GitPullResult) error {
+	result, err := runGit(repoPath, "symbolic-ref", "--quiet", "HEAD")
+	if err != nil {
+		return fmt.Errorf("no branch is currently checked out (detached HEAD)")
+	}
+	head := strings.TrimSpace(result.Stdout)
+
+	result, err = runGit(repoPath, "for-each-ref", "--format=%(upstream:remotename)%1f%(upstream:remoteref)", head)
+	if err != nil {
+		return err
+	}
+	remote, branch, _ := strings.Cut(strings.TrimSpace(result.Stdout), "\x1f")
+	if branch == "" {
+		if pull.Remote != "" {
+			// 指定了远程但没有上游时交给 git 按远程配置决定
+			return nil
+		}
+		return &GitError{
+			Kind:     ErrKindNoUpstream,
+			Message:  fmt.Sprintf("branch %s has no upstream branch", strings.TrimPrefix(head, "refs/heads/")),
+			ExitCode: -1,
+		}
+	}
+	if pull.Remote == "" || pull.Remote == remote {
+		pull.Remote, pull.Branch = remote, branch
+	}
+	return nil
+}
+
+// pullOutcome 根据拉取前后的 HEAD、FETCH_HEAD 与整合方式判断结果
+func pullOutcome(repoPath, strategy, oldHead, newHead string) string {
+	if oldHead == newHead {
+		return models.PullOutcomeUpToDate
+	}
+	if oldHead == "" {
+		return models.PullOutcomeFastForward
+	}
+
+	// FETCH_HEAD 中第一个未标记 not-for-merge 的提交为合入的远程提交
+	if gitDir, err := resolveGitDir(repoPath); err == nil {
+		for _, line := range strings.Split(readGitFile(gitDir, "FETCH_HEAD"), "\n") {
+			fields := strings.SplitN(line, "\t", 3)
+			if len(fields) >= 2 && fields[1] == "" {
+				if fields[0] == newHead {
+					return models.PullOutcomeFastForward
+				}
+				break
+			}
+		}
+	}
+
+	// 只有明确以变基方式拉取时才报告 rebase，其余情况为合并
+	rebase := strategy == models.PullStrategyRebase
+	if strategy == models.PullStrategyDefault {
+		rebase = pullRebaseConfigured(repoPath)
+	}
+	if rebase {
+		return models.PullOutcomeRebase
+	}
+	return models.PullOutcomeMerge
+}
+
+// pullRebaseConfigured 判断未指定整合方式时 git 是否会以变基方式拉取
+// branch.<name>.rebase 优先于 pull.rebase，取值为 true、merges、interactive 等均表示变基
+func pullRebaseConfigured(repoPath string) bool {
+	keys := []string{"pull.rebase"}
+	if result, err := runGit(repoPath, "symbolic-ref", "--quiet", "--short", "HEAD"); err == nil {
+		keys = append([]string{"branch." + strings.TrimSpace(result.Stdout) + ".rebase"}, keys...)
+	}
+	for _, key := range keys {
+		result, err := runGit(repoPath, "config", "--get", key)
+		if err != nil {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(result.Stdout)) {
+		case "false", "no", "off", "0", "":
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// revParseOptional 解析引用，不存在时返回空字符串（如尚无提交的仓库）
+func revParseOptional(repoPath, ref string) string {
+	result, err := runGit(repoPath, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(result.Stdout)
+}
+
+// snapshotRefs 记录远程跟踪分支与标签当前指向的对象
+func snapshotRefs(repoPath string) (map[string]string, error) {
+	result, err := runGit(repoPath, "for-each-ref", "--format=%(objectname) %(refname)", "refs/remotes", "refs/tags")
+	if err != nil {
+		return nil, err
+	}
+
+	refs := map[string]string{}
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		if hash, ref, ok := strings.Cut(line, " "); ok {
+			refs[ref] = hash
+		}
+	}
+	return refs, nil
+}
+
+// diffRefSnapshots 比较两次快照，按引用名排序返回变化
+func diffRefSnapshots(before, after map[string]string) []models.GitUpdatedRef {
+	updated := []models.GitUpdatedRef{}
+	for ref, newHash := range after {
+		if oldHash := before[ref]; oldHash != newHash {
+			updated = append(updated, models.GitUpdatedRef{Ref: ref, OldHash: oldHash, NewHash: newHash})
+		}
+	}
+	for ref, oldHash := range before {
+		if _, ok := after[ref]; !ok {
+			updated = append(updated, models.GitUpdatedRef{Ref: ref, OldHash: oldHash})
+		}
+	}
+	sort.Slice(updated, func(i, j int) bool {
+		return updated[i].Ref < updated[j].Ref
+	})
+	return updated
+}
@@ -0,0 +1,106 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-git-client-window/models"
+)
+
+func TestPullWithOptionsOutcome(t *testing.T) {
+	ctx := context.Background()
+	dir, remote := newDivergedPushRepo(t)
+	remoteHead := strings.TrimSpace(mustGit(t, remote, "rev-parse", "master"))
+
+	_, err := gitService.PullWithOptions(ctx, dir, models.GitPullOptions{Strategy: "octopus"}, nil)
+	assert.Error(t, err)
+
+	// 分叉时 ff-only 失败
+	_, err = gitService.PullWithOptions(ctx, dir, models.GitPullOptions{Strategy: models.PullStrategyFFOnly}, nil)
+	assert.Error(t, err)
+
+	// 工作区有改动时 --autostash 保留改动
+	writeTestFile(t, dir, "local.txt", "dirty\n")
+	oldHead := strings.TrimSpace(mustGit(t, dir, "rev-parse", "HEAD"))
+	pull, err := gitService.PullWithOptions(ctx, dir, models.GitPullOptions{Strategy: models.PullStrategyRebase, Autostash: true}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.PullOutcomeRebase, pull.Outcome)
+	assert.Equal(t, "origin", pull.Remote)
+	assert.Equal(t, "refs/heads/master", pull.Branch)
+	assert.Equal(t, oldHead, pull.OldHead)
+	assert.Equal(t, remoteHead, strings.TrimSpace(mustGit(t, dir, "rev-parse", "HEAD~1")))
+	assert.Contains(t, mustGit(t, dir, "status", "--porcelain"), "local.txt")
+	mustGit(t, dir, "checkout", "--", "local.txt")
+
+	// 已是最新
+	pull, err = gitService.PullWithOptions(ctx, dir, models.GitPullOptions{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.PullOutcomeUpToDate, pull.Outcome)
+	assert.Empty(t, pull.UpdatedRefs)
+
+	// 远程新增提交与标签后 merge 策略产生合并提交
+	other := t.TempDir()
+	mustGit(t, other, "clone", "-q", remote, ".")
+	writeTestFile(t, other, "second.txt", "second\n")
+	mustGit(t, other, "add", "second.txt")
+	mustGit(t, other, "commit", "-m", "second teammate change")
+	mustGit(t, other, "tag", "v1.0")
+	mustGit(t, other, "push", "-q", "origin", "master", "v1.0")
+	writeTestFile(t, dir, "third.txt", "third\n")
+	mustGit(t, dir, "add", "third.txt")
+	mustGit(t, dir, "commit", "-m", "another local change")
+
+	pull, err = gitService.PullWithOptions(ctx, dir, models.GitPullOptions{Strategy: models.PullStrategyMerge}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.PullOutcomeMerge, pull.Outcome)
+	newRemoteHead := strings.TrimSpace(mustGit(t, remote, "rev-parse", "master"))
+	assert.Equal(t, []models.GitUpdatedRef{
+		{Ref: "refs/remotes/origin/master", OldHash: remoteHead, NewHash: newRemoteHead},
+		{Ref: "refs/tags/v1.0", NewHash: newRemoteHead},
+	}, pull.UpdatedRefs)
+
+	// 未指定整合方式时按 pull.rebase 配置判断是否为变基
+	writeTestFile(t, other, "fourth.txt", "fourth\n")
+	mustGit(t, other, "add", "fourth.txt")
+	mustGit(t, other, "commit", "-m", "fourth teammate change")
+	mustGit(t, other, "push", "-q", "origin", "master")
+	writeTestFile(t, dir, "fifth.txt", "fifth\n")
+	mustGit(t, dir, "add", "fifth.txt")
+	mustGit(t, dir, "commit", "-m", "fifth local change")
+	mustGit(t, dir, "config", "pull.rebase", "true")
+	pull, err = gitService.PullWithOptions(ctx, dir, models.GitPullOptions{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.PullOutcomeRebase, pull.Outcome)
+
+	_, err = gitService.PullWithOptions(ctx, dir, models.GitPullOptions{Branch: "--upload-pack=touch x"}, nil)
+	assert.ErrorContains(t, err, "invalid revision")
+}
+
+func TestPullWithOptionsFastForwardAndPrune(t *testing.T) {
+	ctx := context.Background()
+	dir, remote := newDivergedPushRepo(t)
+	mustGit(t, dir, "reset", "-q", "--hard", "HEAD~1")
+	mustGit(t, remote, "branch", "stale", "master")
+	mustGit(t, dir, "fetch", "-q")
+	staleHash := strings.TrimSpace(mustGit(t, dir, "rev-parse", "origin/stale"))
+	mustGit(t, remote, "branch", "-D", "stale")
+
+	pull, err := gitService.PullWithOptions(ctx, dir, models.GitPullOptions{Prune: true}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.PullOutcomeFastForward, pull.Outcome)
+	assert.Equal(t, strings.TrimSpace(mustGit(t, remote, "rev-parse", "master")), pull.NewHead)
+	assert.Contains(t, pull.UpdatedRefs, models.GitUpdatedRef{Ref: "refs/remotes/origin/stale", OldHash: staleHash})
+}
+
+func TestPullWithOptionsNoUpstream(t *testing.T) {
+	dir := newTestRepo(t)
+	_, err := gitService.PullWithOptions(context.Background(), dir, models.GitPullOptions{}, nil)
+	require.Error(t, err)
+	var gitErr *GitError
+	require.ErrorAs(t, err, &gitErr)
+	assert.Equal(t, ErrKindNoUpstream, gitErr.Kind)
+}
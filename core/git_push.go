@@ -0,0 +1,287 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"go-git-client-window/models"
+)
+
+// protectedBranchKey 仓库级受保护分支规则的配置项，可设置多个通配符，如 main、release/*
+const protectedBranchKey = "gitclient.protectedBranch"
+
+// pushTarget 一次推送中的单个目标
+type pushTarget struct {
+	source      string // 本地引用，删除时为空
+	destination string // 远程引用（refs/heads/...）
+	force       bool   // refspec 以 + 开头
+}
+
+// PushWithOptions 推送到指定远程仓库
+// 强制推送（含 +refspec 与删除远程分支）命中受保护分支规则时直接拒绝，不会执行 git push
+func (s *GitCoreService) PushWithOptions(ctx context.Context, repoPath string, opts models.GitPushOptions, onProgress ProgressFunc) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+
+	remote, targets, err := resolvePushTargets(repoPath, opts)
+	if err != nil {
+		return "", err
+	}
+	if err := checkPushProtection(repoPath, opts, targets); err != nil {
+		return "", err
+	}
+
+	args := []string{"push", "--progress"}
+	switch opts.Force {
+	case models.PushForceNone:
+	case models.PushForceWithLease:
+		lease := "--force-with-lease"
+		if opts.ExpectedRemoteHash != "" {
+			if len(targets) != 1 {
+				return "", fmt.Errorf("expected remote hash requires exactly one push target")
+			}
+			lease += "=" + targets[0].destination + ":" + opts.ExpectedRemoteHash
+		}
+		args = append(args, lease)
+		if opts.ForceIfIncludes {
+			args = append(args, "--force-if-includes")
+		}
+	case models.PushForceAlways:
+		args = append(args, "--force")
+	default:
+		return "", fmt.Errorf("unsupported push force mode: %s", opts.Force)
+	}
+	// 未指定远程与 refspec 时推送当前分支到其上游
+	switch {
+	case len(opts.Refspecs) > 0:
+		args = append(args, remote)
+		args = append(args, opts.Refspecs...)
+	case opts.Remote != "":
+		args = append(args, opts.Remote)
+	}
+
+	result, err := runWithProgress(ctx, repoPath, onProgress, args...)
+	if err != nil {
+		return "", err
+	}
+
+	return progressOutput(result), nil
+}
+
+// PreviewPush 预览推送：查询远程分支当前位置，列出强制推送后会被覆盖的提交
+func (s *GitCoreService) PreviewPush(ctx context.Context, repoPath string, opts models.GitPushOptions) (*models.GitPushPreview, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+
+	remote, targets, err := resolvePushTargets(repoPath, opts)
+	if err != nil {
+		return nil, err
+	}
+	patterns, err := protectedBranches(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"ls-remote", remote}
+	for _, target := range targets {
+		args = append(args, target.destination)
+	}
+	output, err := ExecuteGitCommandContext(ctx, repoPath, args...)
+	if err != nil {
+		return nil, err
+	}
+	remoteRefs := map[string]string{}
+	for _, line := range strings.Split(output, "\n") {
+		if hash, ref, ok := strings.Cut(strings.TrimSpace(line), "\t"); ok {
+			remoteRefs[ref] = hash
+		}
+	}
+
+	preview := &models.GitPushPreview{Remote: remote, Refs: []models.GitPushRefPreview{}}
+	for _, target := range targets {
+		ref := models.GitPushRefPreview{
+			Source:      target.source,
+			Destination: target.destination,
+			RemoteHash:  remoteRefs[target.destination],
+			Delete:      target.source == "",
+			Protected:   isProtectedBranch(patterns, target.destination),
+			Overwritten: []models.GitLogEntry{},
+		}
+		ref.NewBranch = ref.RemoteHash == ""
+
+		if !ref.Delete {
+			result, err := runGit(repoPath, "rev-parse", "--verify", target.source+"^{commit}")
+			if err != nil {
+				return nil, err
+			}
+			ref.LocalHash = strings.TrimSpace(result.Stdout)
+		}
+
+		if !ref.NewBranch {
+			if _, err := runGit(repoPath, "cat-file", "-e", ref.RemoteHash+"^{commit}"); err != nil {
+				ref.MissingRemoteCommits = true
+			} else {
+				if ref.Delete {
+					// 删除时列出不在任何本地分支上的提交
+					result, err := runGit(repoPath, "log", "-z", "--format="+logFormat, "-n", strconv.Itoa(CompareCommitLimit), ref.RemoteHash, "--not", "--branches", "--")
+					if err != nil {
+						return nil, err
+					}
+					ref.Overwritten, err = ParseLogRecords(result.Stdout)
+					if err != nil {
+						return nil, err
+					}
+				} else if ref.Overwritten, err = compareCommits(repoPath, ref.LocalHash, ref.RemoteHash); err != nil {
+					return nil, err
+				}
+				ref.FastForward = !ref.Delete && len(ref.Overwritten) == 0
+			}
+		} else {
+			ref.FastForward = !ref.Delete
+		}
+
+		preview.Refs = append(preview.Refs, ref)
+	}
+
+	return preview, nil
+}
+
+// GetProtectedBranches 获取仓库配置的受保护分支规则
+func (s *GitCoreService) GetProtectedBranches(repoPath string) ([]string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+
+	return protectedBranches(repoPath)
+}
+
+// protectedBranches 读取 gitclient.protectedBranch 配置，未配置时返回空列表
+func protectedBranches(repoPath string) ([]string, error) {
+	// 未配置时 --get-all 以退出码 1 结束
+	result, err := runGit(repoPath, "config", "--get-all", protectedBranchKey)
+	if err != nil && (result == nil || result.ExitCode != 1) {
+		return nil, err
+	}
+
+	patterns := []string{}
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			patterns = append(patterns, line)
+		}
+	}
+	return patterns, nil
+}
+
+// SetProtectedBranches 设置仓库的受保护分支规则（写入 .git/config），传入空列表表示清除
+func (s *GitCoreService) SetProtectedBranches(repoPath string, patterns []string) error {
+	if strings.TrimSpace(repoPath) == "" {
+		return fmt.Errorf("path cannot be empty")
+	}
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid branch pattern %q: %w", pattern, err)
+		}
+	}
+
+	// 未配置过时 --unset-all 以退出码 5 结束
+	result, err := runGit(repoPath, "config", "--local", "--unset-all", protectedBranchKey)
+	if err != nil && (result == nil || result.ExitCode != 5) {
+		return err
+	}
+	for _, pattern := range patterns {
+		if _, err := runGit(repoPath, "config", "--local", "--add", protectedBranchKey, pattern); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolvePushTargets 解析推送的远程仓库与目标分支
+// 未指定 refspec 时按当前分支的推送目标（%(push)）推断，未配置时推送到同名分支
+func resolvePushTargets(repoPath string, opts models.GitPushOptions) (string, []pushTarget, error) {
+	if len(opts.Refspecs) == 0 {
+		result, err := runGit(repoPath, "symbolic-ref", "--quiet", "HEAD")
+		if err != nil {
+			return "", nil, fmt.Errorf("no branch is currently checked out (detached HEAD)")
+		}
+		head := strings.TrimSpace(result.Stdout)
+
+		result, err = runGit(repoPath, "for-each-ref", "--format=%(push:remotename)%1f%(push:remoteref)", head)
+		if err != nil {
+			return "", nil, err
+		}
+		remote, destination, _ := strings.Cut(strings.TrimSpace(result.Stdout), "\x1f")
+		if opts.Remote != "" {
+			remote = opts.Remote
+		}
+		if destination == "" {
+			destination = head
+		}
+		return defaultRemote(remote), []pushTarget{{source: head, destination: destination}}, nil
+	}
+
+	targets := make([]pushTarget, 0, len(opts.Refspecs))
+	for _, refspec := range opts.Refspecs {
+		target := pushTarget{}
+		refspec, target.force = strings.CutPrefix(refspec, "+")
+		source, destination, ok := strings.Cut(refspec, ":")
+		if !ok {
+			destination = source
+		}
+		if source == "HEAD" && !ok {
+			return "", nil, fmt.Errorf("refspec HEAD requires an explicit destination")
+		}
+		target.source = source
+		target.destination = qualifyBranchRef(destination)
+		targets = append(targets, target)
+	}
+	return defaultRemote(opts.Remote), targets, nil
+}
+
+// qualifyBranchRef 将简写的分支名补全为 refs/heads/...
+func qualifyBranchRef(ref string) string {
+	if strings.HasPrefix(ref, "refs/") {
+		return ref
+	}
+	return "refs/heads/" + ref
+}
+
+// checkPushProtection 强制推送或删除受保护分支时返回 ProtectedBranch 错误
+func checkPushProtection(repoPath string, opts models.GitPushOptions, targets []pushTarget) error {
+	patterns, err := protectedBranches(repoPath)
+	if err != nil {
+		return err
+	}
+
+	for _, target := range targets {
+		destructive := opts.Force != models.PushForceNone || target.force || target.source == ""
+		if destructive && isProtectedBranch(patterns, target.destination) {
+			name := strings.TrimPrefix(target.destination, "refs/heads/")
+			return &GitError{
+				Kind:     ErrKindProtectedBranch,
+				Message:  fmt.Sprintf("force push to protected branch %s is not allowed", name),
+				ExitCode: -1,
+			}
+		}
+	}
+	return nil
+}
+
+// isProtectedBranch 判断远程引用是否命中受保护分支规则（path.Match 通配符，* 不跨越 /）
+func isProtectedBranch(patterns []string, ref string) bool {
+	name, ok := strings.CutPrefix(ref, "refs/heads/")
+	if !ok {
+		return false
+	}
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-git-client-window/models"
+)
+
+// newDivergedPushRepo 创建与远程分叉的仓库：远程 master 有本地没有的 "teammate change"
+func newDivergedPushRepo(t *testing.T) (dir, remote string) {
+	t.Helper()
+	dir = newTestRepo(t)
+	remote = t.TempDir()
+	mustGit(t, remote, "init", "--bare")
+	mustGit(t, dir, "remote", "add", "origin", remote)
+	mustGit(t, dir, "push", "-q", "-u", "origin", "master")
+
+	other := t.TempDir()
+	mustGit(t, other, "clone", "-q", remote, ".")
+	writeTestFile(t, other, "teammate.txt", "teammate\n")
+	mustGit(t, other, "add", "teammate.txt")
+	mustGit(t, other, "commit", "-m", "teammate change")
+	mustGit(t, other, "push", "-q", "origin", "master")
+
+	writeTestFile(t, dir, "local.txt", "local\n")
+	mustGit(t, dir, "add", "local.txt")
+	mustGit(t, dir, "commit", "-m", "local change")
+	return dir, remote
+}
+
+func TestPreviewPush(t *testing.T) {
+	ctx := context.Background()
+	dir, remote := newDivergedPushRepo(t)
+	remoteHead := strings.TrimSpace(mustGit(t, remote, "rev-parse", "master"))
+
+	// 未获取远程提交时无法列出被覆盖的提交
+	preview, err := gitService.PreviewPush(ctx, dir, models.GitPushOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "origin", preview.Remote)
+	require.Len(t, preview.Refs, 1)
+	ref := preview.Refs[0]
+	assert.Equal(t, "refs/heads/master", ref.Destination)
+	assert.Equal(t, remoteHead, ref.RemoteHash)
+	assert.True(t, ref.MissingRemoteCommits)
+
+	mustGit(t, dir, "fetch", "-q")
+	preview, err = gitService.PreviewPush(ctx, dir, models.GitPushOptions{Refspecs: []string{"master", "master:feature", ":old"}})
+	require.NoError(t, err)
+	require.Len(t, preview.Refs, 3)
+	ref = preview.Refs[0]
+	assert.False(t, ref.FastForward)
+	require.Len(t, ref.Overwritten, 1)
+	assert.Equal(t, "teammate change", ref.Overwritten[0].Subject)
+	assert.True(t, preview.Refs[1].NewBranch)
+	assert.True(t, preview.Refs[1].FastForward)
+	assert.True(t, preview.Refs[2].Delete)
+}
+
+func TestPushWithOptionsSafety(t *testing.T) {
+	ctx := context.Background()
+	dir, remote := newDivergedPushRepo(t)
+	mustGit(t, dir, "fetch", "-q")
+	remoteHead := strings.TrimSpace(mustGit(t, remote, "rev-parse", "master"))
+
+	_, err := gitService.PushWithOptions(ctx, dir, models.GitPushOptions{}, nil)
+	assert.True(t, IsGitErrorKind(err, ErrKindRejected))
+
+	// 受保护分支禁止任何形式的强制推送与删除
+	require.NoError(t, gitService.SetProtectedBranches(dir, []string{"master", "release/*"}))
+	patterns, err := gitService.GetProtectedBranches(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"master", "release/*"}, patterns)
+	for _, opts := range []models.GitPushOptions{
+		{Force: models.PushForceWithLease},
+		{Force: models.PushForceAlways, Refspecs: []string{"master"}},
+		{Refspecs: []string{"+master"}},
+		{Refspecs: []string{":release/1.0"}},
+	} {
+		_, err = gitService.PushWithOptions(ctx, dir, opts, nil)
+		assert.True(t, IsGitErrorKind(err, ErrKindProtectedBranch), "%+v", opts)
+	}
+	_, err = gitService.DeleteRemoteBranch(ctx, dir, "", "master")
+	assert.True(t, IsGitErrorKind(err, ErrKindProtectedBranch))
+	// 重命名上游会删除旧的远程分支，被拒绝时本地分支也保持不变
+	_, err = gitService.RenameBranch(ctx, dir, "master", "main", true, nil)
+	assert.True(t, IsGitErrorKind(err, ErrKindProtectedBranch))
+	assert.Equal(t, "master\n", mustGit(t, dir, "branch", "--show-current"))
+	preview, err := gitService.PreviewPush(ctx, dir, models.GitPushOptions{})
+	require.NoError(t, err)
+	assert.True(t, preview.Refs[0].Protected)
+	// 普通推送与非受保护分支不受影响
+	_, err = gitService.PushWithOptions(ctx, dir, models.GitPushOptions{Refspecs: []string{"+master:topic"}}, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, gitService.SetProtectedBranches(dir, nil))
+	assert.Error(t, gitService.SetProtectedBranches(dir, []string{"[bad"}))
+
+	// 远程跟踪分支的改动未被本地包含时 --force-if-includes 拒绝
+	_, err = gitService.PushWithOptions(ctx, dir, models.GitPushOptions{Force: models.PushForceWithLease, ForceIfIncludes: true}, nil)
+	assert.True(t, IsGitErrorKind(err, ErrKindRejected))
+
+	// 预期的远程提交与实际不符时拒绝
+	_, err = gitService.PushWithOptions(ctx, dir, models.GitPushOptions{
+		Force:              models.PushForceWithLease,
+		Refspecs:           []string{"master"},
+		ExpectedRemoteHash: strings.TrimSpace(mustGit(t, dir, "rev-parse", "HEAD~1")),
+	}, nil)
+	assert.True(t, IsGitErrorKind(err, ErrKindRejected))
+
+	_, err = gitService.PushWithOptions(ctx, dir, models.GitPushOptions{
+		Force:              models.PushForceWithLease,
+		Refspecs:           []string{"master"},
+		ExpectedRemoteHash: remoteHead,
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "local change\n", mustGit(t, remote, "log", "-1", "--format=%s", "master"))
+
+	_, err = gitService.PushWithOptions(ctx, dir, models.GitPushOptions{Force: "bogus"}, nil)
+	assert.Error(t, err)
+}
+
+func TestIsProtectedBranch(t *testing.T) {
+	patterns := []string{"main", "release/*"}
+	assert.True(t, isProtectedBranch(patterns, "refs/heads/main"))
+	assert.True(t, isProtectedBranch(patterns, "refs/heads/release/1.0"))
+	assert.False(t, isProtectedBranch(patterns, "refs/heads/release/1.0/hotfix"))
+	assert.False(t, isProtectedBranch(patterns, "refs/heads/feature"))
+	assert.False(t, isProtectedBranch(patterns, "refs/tags/main"))
+}
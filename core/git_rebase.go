@@ -0,0 +1,310 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go-git-client-window/models"
+)
+
+// rebaseStateDir 交互式变基期间保存计划与提交信息的目录（位于 .git 下）
+const rebaseStateDir = "go-git-client-rebase"
+
+// GetRebasePlan 获取 base..HEAD 之间待变基的提交，按从旧到新排列，默认动作为 pick
+func (s *GitCoreService) GetRebasePlan(repoPath, base string) ([]models.GitRebaseTodoItem, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+	if err := checkRevisionArgs([]string{base}); err != nil {
+		return nil, err
+	}
+
+	result, err := runGit(repoPath, "log", "--reverse", "--no-merges", "--format=%H\x1f%s", base+"..HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	items := []models.GitRebaseTodoItem{}
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		hash, subject, ok := strings.Cut(line, "\x1f")
+		if !ok {
+			continue
+		}
+		items = append(items, models.GitRebaseTodoItem{Action: models.RebaseActionPick, Commit: hash, Subject: subject})
+	}
+
+	return items, nil
+}
+
+// RebaseInteractive 按前端提交的计划执行交互式变基
+// 通过 GIT_SEQUENCE_EDITOR 将生成的 todo 写入 git，reword/squash 的新信息由 GIT_EDITOR 辅助脚本写入
+func (s *GitCoreService) RebaseInteractive(repoPath string, plan models.GitRebasePlan) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(plan.Base) == "" {
+		return "", fmt.Errorf("rebase base cannot be empty")
+	}
+	if plan.Autosquash && len(plan.Items) > 0 {
+		// 自定义计划会整体替换 git 生成的 todo，autosquash 的排序不会生效
+		return "", fmt.Errorf("autosquash cannot be combined with a custom rebase plan")
+	}
+	if err := checkRevisionArgs([]string{plan.Base}); err != nil {
+		return "", err
+	}
+
+	// 进行中的变基仍在使用状态目录中的信息与辅助脚本，不能清理后重新开始
+	state, err := s.GetRepoState(repoPath)
+	if err != nil {
+		return "", err
+	}
+	switch state.Operation {
+	case models.RepoOperationRebase, models.RepoOperationRebaseInteractive, models.RepoOperationApplyMailbox:
+		return "", fmt.Errorf("a rebase is already in progress")
+	}
+
+	items, err := resolveRebaseItems(repoPath, plan.Items)
+	if err != nil {
+		return "", err
+	}
+
+	gitDir, err := resolveGitDir(repoPath)
+	if err != nil {
+		return "", err
+	}
+	stateDir := filepath.Join(gitDir, rebaseStateDir)
+	if err := os.RemoveAll(stateDir); err != nil {
+		return "", err
+	}
+	defer cleanupRebaseState(gitDir)
+
+	// 未提供计划时接受 git 生成的 todo（配合 --autosquash 使用）
+	sequenceEditor := "true"
+	if len(items) > 0 {
+		messageDir := filepath.Join(stateDir, "messages")
+		if err := os.MkdirAll(messageDir, 0o755); err != nil {
+			return "", err
+		}
+		todo, err := BuildRebaseTodo(items, messageDir)
+		if err != nil {
+			return "", err
+		}
+		todoFile := filepath.Join(stateDir, "todo")
+		if err := os.WriteFile(todoFile, []byte(todo), 0o644); err != nil {
+			return "", err
+		}
+		if err := writeRebaseEditor(gitDir); err != nil {
+			return "", err
+		}
+		sequenceEditor = "cp " + shellQuote(todoFile)
+	}
+
+	args := []string{"rebase", "--interactive"}
+	if plan.Autosquash {
+		args = append(args, "--autosquash")
+	} else {
+		args = append(args, "--no-autosquash")
+	}
+	if plan.Autostash {
+		args = append(args, "--autostash")
+	}
+	args = append(args, plan.Base)
+
+	result, err := RunGitCommand(context.Background(), GitCommandOptions{
+		Dir:  repoPath,
+		Args: args,
+		Env:  []string{"GIT_SEQUENCE_EDITOR=" + sequenceEditor, rebaseEditorEnv(gitDir)},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return result.CombinedOutput(), nil
+}
+
+// resolveRebaseItems 将计划中的提交解析为完整哈希，拒绝选项形式或不存在的提交
+func resolveRebaseItems(repoPath string, items []models.GitRebaseTodoItem) ([]models.GitRebaseTodoItem, error) {
+	resolved := make([]models.GitRebaseTodoItem, len(items))
+	for i, item := range items {
+		resolved[i] = item
+		if item.Action == models.RebaseActionExec {
+			continue
+		}
+		if strings.TrimSpace(item.Commit) == "" {
+			return nil, fmt.Errorf("item %d: commit cannot be empty", i)
+		}
+		if err := checkRevisionArgs([]string{item.Commit}); err != nil {
+			return nil, err
+		}
+		result, err := runGit(repoPath, "rev-parse", "--verify", "--quiet", item.Commit+"^{commit}")
+		if err != nil {
+			return nil, fmt.Errorf("item %d: unknown commit %q", i, item.Commit)
+		}
+		resolved[i].Commit = strings.TrimSpace(result.Stdout)
+	}
+	return resolved, nil
+}
+
+// isFullCommitHash 判断是否为完整的 SHA-1 或 SHA-256 提交哈希
+func isFullCommitHash(commit string) bool {
+	if len(commit) != 40 && len(commit) != 64 {
+		return false
+	}
+	for _, c := range commit {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// BuildRebaseTodo 根据计划生成 git-rebase-todo 内容，提交必须为完整哈希（见 resolveRebaseItems）
+// reword 与 squash 的新信息以提交哈希命名写入 messageDir，squash 的信息以所在压缩链最后一项命名，
+// 因为 git 在整条链处理完后才打开编辑器
+func BuildRebaseTodo(items []models.GitRebaseTodoItem, messageDir string) (string, error) {
+	var todo strings.Builder
+	messages := map[string]string{}
+	chainEnd, chainMessage := "", ""
+
+	flushChain := func() {
+		if chainMessage != "" {
+			messages[chainEnd] = chainMessage
+		}
+		chainEnd, chainMessage = "", ""
+	}
+
+	for i, item := range items {
+		switch item.Action {
+		case models.RebaseActionExec:
+			if strings.TrimSpace(item.Command) == "" || strings.Contains(item.Command, "\n") {
+				return "", fmt.Errorf("item %d: invalid exec command", i)
+			}
+			flushChain()
+			fmt.Fprintf(&todo, "exec %s\n", item.Command)
+			continue
+		case models.RebaseActionPick, models.RebaseActionReword, models.RebaseActionEdit,
+			models.RebaseActionSquash, models.RebaseActionFixup, models.RebaseActionDrop:
+		default:
+			return "", fmt.Errorf("item %d: unsupported rebase action %q", i, item.Action)
+		}
+		// 提交同时写入 todo 并作为信息文件名，只接受完整哈希以免换行注入或路径穿越
+		if !isFullCommitHash(item.Commit) {
+			return "", fmt.Errorf("item %d: commit must be a full hash: %q", i, item.Commit)
+		}
+		if i == 0 && (item.Action == models.RebaseActionSquash || item.Action == models.RebaseActionFixup) {
+			return "", fmt.Errorf("cannot %s without a previous commit", item.Action)
+		}
+
+		switch item.Action {
+		case models.RebaseActionSquash, models.RebaseActionFixup:
+			chainEnd = item.Commit
+			if item.Action == models.RebaseActionSquash && item.Message != "" {
+				chainMessage = item.Message
+			}
+		case models.RebaseActionDrop:
+		default:
+			flushChain()
+			chainEnd = item.Commit
+			if item.Action == models.RebaseActionReword {
+				if item.Message == "" {
+					return "", fmt.Errorf("item %d: reword requires a message", i)
+				}
+				messages[item.Commit] = item.Message
+			}
+		}
+		fmt.Fprintf(&todo, "%s %s\n", item.Action, item.Commit)
+	}
+	flushChain()
+
+	for commit, message := range messages {
+		if err := os.WriteFile(filepath.Join(messageDir, commit), []byte(message), 0o644); err != nil {
+			return "", err
+		}
+	}
+
+	return todo.String(), nil
+}
+
+// rebaseEditorScript GIT_EDITOR 辅助脚本：按 rebase-merge/done 最后一条命令的提交找到预先写入的信息，
+// 覆盖 git 打开的提交信息文件；没有对应信息时保留 git 的默认信息
+const rebaseEditorScript = `#!/bin/sh
+commit=$(sed -n '$s/^[a-z]* \([0-9a-f]*\).*/\1/p' %s)
+[ -n "$commit" ] || exit 0
+for message in %s/*; do
+	name=${message##*/}
+	case "$commit" in "$name"*) cp "$message" "$1"; exit 0 ;; esac
+	case "$name" in "$commit"*) cp "$message" "$1"; exit 0 ;; esac
+done
+`
+
+// writeRebaseEditor 在变基状态目录中生成 GIT_EDITOR 辅助脚本
+func writeRebaseEditor(gitDir string) error {
+	stateDir := filepath.Join(gitDir, rebaseStateDir)
+	script := fmt.Sprintf(rebaseEditorScript,
+		shellQuote(filepath.Join(gitDir, "rebase-merge", "done")),
+		shellQuote(filepath.Join(stateDir, "messages")))
+	return os.WriteFile(filepath.Join(stateDir, "editor.sh"), []byte(script), 0o755)
+}
+
+// rebaseEditorEnv 存在辅助脚本时使用它作为 GIT_EDITOR，否则直接接受默认信息
+func rebaseEditorEnv(gitDir string) string {
+	script := filepath.Join(gitDir, rebaseStateDir, "editor.sh")
+	if fileExists(script) {
+		return "GIT_EDITOR=sh " + shellQuote(script)
+	}
+	return "GIT_EDITOR=true"
+}
+
+// cleanupRebaseState 变基已结束（完成、放弃或未能开始）时删除状态目录
+func cleanupRebaseState(gitDir string) {
+	if !fileExists(filepath.Join(gitDir, "rebase-merge")) {
+		_ = os.RemoveAll(filepath.Join(gitDir, rebaseStateDir))
+	}
+}
+
+// RebaseContinue 解决冲突或完成 edit 后继续变基
+func (s *GitCoreService) RebaseContinue(repoPath string) (string, error) {
+	return s.controlRebase(repoPath, "--continue")
+}
+
+// RebaseSkip 跳过当前提交
+func (s *GitCoreService) RebaseSkip(repoPath string) (string, error) {
+	return s.controlRebase(repoPath, "--skip")
+}
+
+// RebaseAbort 放弃变基，恢复到变基前的状态
+func (s *GitCoreService) RebaseAbort(repoPath string) (string, error) {
+	return s.controlRebase(repoPath, "--abort")
+}
+
+// controlRebase 对进行中的变基执行 --continue/--skip/--abort，变基结束后清理状态目录
+func (s *GitCoreService) controlRebase(repoPath, action string) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+
+	gitDir, err := resolveGitDir(repoPath)
+	if err != nil {
+		return "", err
+	}
+	defer cleanupRebaseState(gitDir)
+
+	result, err := RunGitCommand(context.Background(), GitCommandOptions{
+		Dir:  repoPath,
+		Args: []string{"rebase", action},
+		Env:  []string{rebaseEditorEnv(gitDir)},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return result.CombinedOutput(), nil
+}
+
+// shellQuote 用单引号包裹路径，供 git 通过 sh 执行的命令使用
+func shellQuote(path string) string {
+	return "'" + strings.ReplaceAll(filepath.ToSlash(path), "'", `'\''`) + "'"
+}
@@ -0,0 +1,167 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-git-client-window/models"
+)
+
+// newRebaseRepo 创建包含 one/two/three 三个提交的仓库
+func newRebaseRepo(t *testing.T) string {
+	dir := newTestRepo(t)
+	for _, name := range []string{"one", "two", "three"} {
+		writeTestFile(t, dir, name+".txt", name+"\n")
+		mustGit(t, dir, "add", name+".txt")
+		mustGit(t, dir, "commit", "-m", name)
+	}
+	return dir
+}
+
+func TestRebaseInteractive(t *testing.T) {
+	dir := newRebaseRepo(t)
+
+	plan, err := gitService.GetRebasePlan(dir, "HEAD~3")
+	require.NoError(t, err)
+	require.Len(t, plan, 3)
+	assert.Equal(t, "one", plan[0].Subject)
+	assert.Equal(t, models.RebaseActionPick, plan[0].Action)
+
+	// 调整顺序、改写信息、压缩并丢弃提交
+	items := []models.GitRebaseTodoItem{
+		{Action: models.RebaseActionReword, Commit: plan[2].Commit, Message: "third first"},
+		{Action: models.RebaseActionPick, Commit: plan[0].Commit},
+		{Action: models.RebaseActionSquash, Commit: plan[1].Commit, Message: "one and two"},
+		{Action: models.RebaseActionExec, Command: "git tag rebased"},
+	}
+	_, err = gitService.RebaseInteractive(dir, models.GitRebasePlan{Base: "HEAD~3", Items: items})
+	require.NoError(t, err)
+
+	assert.Equal(t, "one and two\nthird first\nInitial commit\n", mustGit(t, dir, "log", "--format=%s"))
+	assert.Contains(t, mustGit(t, dir, "ls-files"), "two.txt")
+	assert.NotEmpty(t, mustGit(t, dir, "rev-parse", "rebased"))
+
+	state, err := gitService.GetRepoState(dir)
+	require.NoError(t, err)
+	assert.Equal(t, models.RepoOperationNone, state.Operation)
+	assert.NoDirExists(t, filepath.Join(dir, ".git", rebaseStateDir))
+}
+
+func TestRebaseInteractiveResolvesCommits(t *testing.T) {
+	dir := newRebaseRepo(t)
+
+	// 引用名解析为完整哈希后，reword 的信息仍能被编辑器辅助脚本找到
+	_, err := gitService.RebaseInteractive(dir, models.GitRebasePlan{Base: "HEAD~1", Items: []models.GitRebaseTodoItem{
+		{Action: models.RebaseActionReword, Commit: "HEAD", Message: "three by ref"},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "three by ref\n", mustGit(t, dir, "log", "-1", "--format=%s"))
+
+	_, err = gitService.RebaseInteractive(dir, models.GitRebasePlan{Base: "HEAD~1", Items: []models.GitRebaseTodoItem{
+		{Action: models.RebaseActionPick, Commit: "no-such-commit"},
+	}})
+	assert.Error(t, err)
+	_, err = gitService.RebaseInteractive(dir, models.GitRebasePlan{Base: "--root"})
+	assert.ErrorContains(t, err, "invalid revision")
+	_, err = gitService.GetRebasePlan(dir, "--all")
+	assert.ErrorContains(t, err, "invalid revision")
+}
+
+func TestRebaseInteractiveAutosquash(t *testing.T) {
+	dir := newRebaseRepo(t)
+	writeTestFile(t, dir, "one.txt", "one fixed\n")
+	mustGit(t, dir, "commit", "-am", "fixup! one")
+
+	// 自定义计划会替换 git 生成的 todo，不能与 autosquash 同时使用
+	plan, err := gitService.GetRebasePlan(dir, "HEAD~4")
+	require.NoError(t, err)
+	_, err = gitService.RebaseInteractive(dir, models.GitRebasePlan{Base: "HEAD~4", Items: plan, Autosquash: true})
+	assert.Error(t, err)
+
+	_, err = gitService.RebaseInteractive(dir, models.GitRebasePlan{Base: "HEAD~4", Autosquash: true})
+	require.NoError(t, err)
+	assert.Equal(t, "three\ntwo\none\nInitial commit\n", mustGit(t, dir, "log", "--format=%s"))
+	assert.Equal(t, "one fixed\n", mustGit(t, dir, "show", "HEAD~2:one.txt"))
+}
+
+func TestRebaseInteractiveEditAndAbort(t *testing.T) {
+	dir := newRebaseRepo(t)
+	plan, err := gitService.GetRebasePlan(dir, "HEAD~2")
+	require.NoError(t, err)
+	plan[0].Action = models.RebaseActionEdit
+
+	_, err = gitService.RebaseInteractive(dir, models.GitRebasePlan{Base: "HEAD~2", Items: plan})
+	require.NoError(t, err)
+	state, err := gitService.GetRepoState(dir)
+	require.NoError(t, err)
+	assert.Equal(t, models.RepoOperationRebaseInteractive, state.Operation)
+
+	_, err = gitService.RebaseAbort(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "three\n", mustGit(t, dir, "log", "-1", "--format=%s"))
+
+	assert.NoDirExists(t, filepath.Join(dir, ".git", rebaseStateDir))
+
+	// edit 停下后 todo 中只有用户的计划，继续后 reword 的信息仍由编辑器辅助脚本写入
+	plan[1].Action = models.RebaseActionReword
+	plan[1].Message = "three reworded"
+	_, err = gitService.RebaseInteractive(dir, models.GitRebasePlan{Base: "HEAD~2", Items: plan})
+	require.NoError(t, err)
+	todo := mustGit(t, dir, "rev-parse", "--git-path", "rebase-merge/git-rebase-todo")
+	content, err := os.ReadFile(filepath.Join(dir, strings.TrimSpace(todo)))
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "exec")
+	assert.DirExists(t, filepath.Join(dir, ".git", rebaseStateDir))
+
+	// 变基进行中时不能重新开始，也不能清理仍在使用的状态目录
+	_, err = gitService.RebaseInteractive(dir, models.GitRebasePlan{Base: "HEAD~1", Items: plan[:1]})
+	assert.ErrorContains(t, err, "already in progress")
+	assert.DirExists(t, filepath.Join(dir, ".git", rebaseStateDir))
+
+	_, err = gitService.RebaseContinue(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "three reworded\ntwo\none\nInitial commit\n", mustGit(t, dir, "log", "--format=%s"))
+	assert.NoDirExists(t, filepath.Join(dir, ".git", rebaseStateDir))
+}
+
+func TestBuildRebaseTodo(t *testing.T) {
+	dir := t.TempDir()
+	a, b, c, d, e := strings.Repeat("a", 40), strings.Repeat("b", 40), strings.Repeat("c", 40), strings.Repeat("d", 40), strings.Repeat("e", 64)
+
+	todo, err := BuildRebaseTodo([]models.GitRebaseTodoItem{
+		{Action: models.RebaseActionPick, Commit: a},
+		{Action: models.RebaseActionSquash, Commit: b, Message: "aaa and bbb"},
+		{Action: models.RebaseActionFixup, Commit: c},
+		{Action: models.RebaseActionDrop, Commit: d},
+		{Action: models.RebaseActionReword, Commit: e, Message: "new"},
+	}, dir)
+	require.NoError(t, err)
+	assert.Equal(t, "pick "+a+"\nsquash "+b+"\nfixup "+c+"\ndrop "+d+"\nreword "+e+"\n", todo)
+
+	// squash 的信息以压缩链最后一项命名
+	message, err := os.ReadFile(filepath.Join(dir, c))
+	require.NoError(t, err)
+	assert.Equal(t, "aaa and bbb", string(message))
+	message, err = os.ReadFile(filepath.Join(dir, e))
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(message))
+	assert.NoFileExists(t, filepath.Join(dir, b))
+
+	_, err = BuildRebaseTodo([]models.GitRebaseTodoItem{{Action: models.RebaseActionSquash, Commit: a}}, dir)
+	assert.Error(t, err)
+	_, err = BuildRebaseTodo([]models.GitRebaseTodoItem{{Action: "bogus", Commit: a}}, dir)
+	assert.Error(t, err)
+	_, err = BuildRebaseTodo([]models.GitRebaseTodoItem{{Action: models.RebaseActionReword, Commit: a}}, dir)
+	assert.Error(t, err)
+
+	// 非完整哈希可能注入 todo 行或作为路径写到目录外
+	for _, commit := range []string{"aaa", "HEAD", "../" + a[3:], a[:39] + "\nexec touch x"} {
+		_, err = BuildRebaseTodo([]models.GitRebaseTodoItem{{Action: models.RebaseActionReword, Commit: commit, Message: "x"}}, dir)
+		assert.Error(t, err, commit)
+	}
+}
@@ -0,0 +1,192 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go-git-client-window/models"
+)
+
+// GetRemoteInfo 获取远程仓库信息，每个远程仓库只返回一条，包含 fetch/push 地址与 refspec
+func (s *GitCoreService) GetRemoteInfo(repoPath string) ([]models.GitRemoteInfo, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+
+	// 没有任何远程配置时 --get-regexp 以退出码 1 结束
+	result, err := runGit(repoPath, "config", "-z", "--get-regexp", `^remote\..*\.(url|pushurl|fetch|push)$`)
+	if err != nil && (result == nil || result.ExitCode != 1) {
+		return nil, err
+	}
+
+	return ParseRemoteConfig(result.Stdout), nil
+}
+
+// ParseRemoteConfig 解析 config -z --get-regexp 输出，记录格式为 "remote.<name>.<key>\n<value>\0"
+// 远程仓库名可能包含点，因此以最后一个点分隔键名
+func ParseRemoteConfig(output string) []models.GitRemoteInfo {
+	remotes := []models.GitRemoteInfo{}
+	index := map[string]int{}
+
+	for _, record := range strings.Split(output, "\x00") {
+		key, value, _ := strings.Cut(record, "\n")
+		key = strings.TrimPrefix(key, "remote.")
+		dot := strings.LastIndex(key, ".")
+		if dot <= 0 {
+			continue
+		}
+		name, field := key[:dot], key[dot+1:]
+
+		i, ok := index[name]
+		if !ok {
+			i = len(remotes)
+			index[name] = i
+			remotes = append(remotes, models.GitRemoteInfo{Name: name, FetchRefspecs: []string{}, PushRefspecs: []string{}})
+		}
+		remote := &remotes[i]
+
+		switch field {
+		case "url":
+			if remote.FetchURL == "" {
+				remote.FetchURL = value
+			}
+		case "pushurl":
+			if remote.PushURL == "" {
+				remote.PushURL = value
+			}
+		case "fetch":
+			remote.FetchRefspecs = append(remote.FetchRefspecs, value)
+		case "push":
+			remote.PushRefspecs = append(remote.PushRefspecs, value)
+		}
+	}
+
+	for i := range remotes {
+		remotes[i].URL = remotes[i].FetchURL
+		if remotes[i].PushURL == "" {
+			remotes[i].PushURL = remotes[i].FetchURL
+		}
+	}
+
+	return remotes
+}
+
+// AddRemote 添加远程仓库
+func (s *GitCoreService) AddRemote(repoPath, name, url string) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(name) == "" || strings.TrimSpace(url) == "" {
+		return "", fmt.Errorf("remote name and url cannot be empty")
+	}
+
+	output, err := ExecuteGitCommand(repoPath, "remote", "add", name, url)
+	if err != nil {
+		return "", err
+	}
+
+	return output, nil
+}
+
+// RemoveRemote 删除远程仓库及其跟踪分支
+func (s *GitCoreService) RemoveRemote(repoPath, name string) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(name) == "" {
+		return "", fmt.Errorf("remote name cannot be empty")
+	}
+
+	output, err := ExecuteGitCommand(repoPath, "remote", "remove", name)
+	if err != nil {
+		return "", err
+	}
+
+	return output, nil
+}
+
+// RenameRemote 重命名远程仓库，跟踪分支与上游配置随之更新
+func (s *GitCoreService) RenameRemote(repoPath, oldName, newName string) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(oldName) == "" || strings.TrimSpace(newName) == "" {
+		return "", fmt.Errorf("remote name cannot be empty")
+	}
+
+	output, err := ExecuteGitCommand(repoPath, "remote", "rename", oldName, newName)
+	if err != nil {
+		return "", err
+	}
+
+	return output, nil
+}
+
+// SetRemoteURL 修改远程仓库地址，push 为 true 时修改推送地址（pushurl）
+func (s *GitCoreService) SetRemoteURL(repoPath, name, url string, push bool) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(name) == "" || strings.TrimSpace(url) == "" {
+		return "", fmt.Errorf("remote name and url cannot be empty")
+	}
+
+	args := []string{"remote", "set-url"}
+	if push {
+		args = append(args, "--push")
+	}
+	args = append(args, name, url)
+
+	output, err := ExecuteGitCommand(repoPath, args...)
+	if err != nil {
+		return "", err
+	}
+
+	return output, nil
+}
+
+// PruneRemote 删除远程已不存在的跟踪分支
+func (s *GitCoreService) PruneRemote(ctx context.Context, repoPath, name string) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+
+	output, err := ExecuteGitCommandContext(ctx, repoPath, "remote", "prune", defaultRemote(name))
+	if err != nil {
+		return "", err
+	}
+
+	return output, nil
+}
+
+// FetchWithOptions 按指定远程仓库与 refspec 获取
+func (s *GitCoreService) FetchWithOptions(ctx context.Context, repoPath string, opts models.GitFetchOptions, onProgress ProgressFunc) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+	if opts.All && (opts.Remote != "" || len(opts.Refspecs) > 0) {
+		return "", fmt.Errorf("fetch --all cannot be combined with a remote or refspec")
+	}
+
+	args := []string{"fetch", "--progress"}
+	if opts.Prune {
+		args = append(args, "--prune")
+	}
+	switch {
+	case opts.All:
+		args = append(args, "--all")
+	case len(opts.Refspecs) > 0:
+		args = append(args, defaultRemote(opts.Remote))
+		args = append(args, opts.Refspecs...)
+	case opts.Remote != "":
+		args = append(args, opts.Remote)
+	}
+
+	result, err := runWithProgress(ctx, repoPath, onProgress, args...)
+	if err != nil {
+		return "", err
+	}
+
+	return progressOutput(result), nil
+}
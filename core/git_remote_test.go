@@ -0,0 +1,102 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-git-client-window/models"
+)
+
+func TestRemoteManagement(t *testing.T) {
+	dir := newTestRepo(t)
+
+	remotes, err := gitService.GetRemoteInfo(dir)
+	require.NoError(t, err)
+	assert.Empty(t, remotes)
+
+	_, err = gitService.AddRemote(dir, "origin", "https://example.com/a.git")
+	require.NoError(t, err)
+	_, err = gitService.AddRemote(dir, "my.fork", "https://example.com/b.git")
+	require.NoError(t, err)
+	_, err = gitService.AddRemote(dir, "origin", "https://example.com/c.git")
+	assert.True(t, IsGitErrorKind(err, ErrKindAlreadyExists))
+
+	_, err = gitService.SetRemoteURL(dir, "origin", "ssh://example.com/a.git", true)
+	require.NoError(t, err)
+	mustGit(t, dir, "config", "--add", "remote.origin.push", "refs/heads/*:refs/heads/*")
+
+	remotes, err = gitService.GetRemoteInfo(dir)
+	require.NoError(t, err)
+	require.Len(t, remotes, 2)
+	assert.Equal(t, models.GitRemoteInfo{
+		Name:          "origin",
+		URL:           "https://example.com/a.git",
+		FetchURL:      "https://example.com/a.git",
+		PushURL:       "ssh://example.com/a.git",
+		FetchRefspecs: []string{"+refs/heads/*:refs/remotes/origin/*"},
+		PushRefspecs:  []string{"refs/heads/*:refs/heads/*"},
+	}, remotes[0])
+	assert.Equal(t, "my.fork", remotes[1].Name)
+	assert.Equal(t, "https://example.com/b.git", remotes[1].PushURL)
+
+	_, err = gitService.RenameRemote(dir, "my.fork", "fork")
+	require.NoError(t, err)
+	_, err = gitService.SetRemoteURL(dir, "fork", "https://example.com/d.git", false)
+	require.NoError(t, err)
+	_, err = gitService.RemoveRemote(dir, "origin")
+	require.NoError(t, err)
+
+	remotes, err = gitService.GetRemoteInfo(dir)
+	require.NoError(t, err)
+	require.Len(t, remotes, 1)
+	assert.Equal(t, "fork", remotes[0].Name)
+	assert.Equal(t, "https://example.com/d.git", remotes[0].FetchURL)
+	assert.Equal(t, []string{"+refs/heads/*:refs/remotes/fork/*"}, remotes[0].FetchRefspecs)
+}
+
+func TestRemoteTargets(t *testing.T) {
+	ctx := context.Background()
+	dir := newTestRepo(t)
+	upstream := t.TempDir()
+	mustGit(t, upstream, "init", "--bare")
+	mirror := t.TempDir()
+	mustGit(t, mirror, "init", "--bare")
+	mustGit(t, dir, "remote", "add", "upstream", upstream)
+	mustGit(t, dir, "remote", "add", "mirror", mirror)
+
+	// 推送到非 origin 的远程，并使用 refspec 改名
+	_, err := gitService.PushWithOptions(ctx, dir, models.GitPushOptions{Remote: "mirror", Refspecs: []string{"HEAD:refs/heads/copy"}}, nil)
+	require.NoError(t, err)
+	assert.Contains(t, mustGit(t, mirror, "branch"), "copy")
+	_, err = gitService.PushWithOptions(ctx, dir, models.GitPushOptions{Remote: "upstream", Refspecs: []string{"master", "master:gone"}}, nil)
+	require.NoError(t, err)
+
+	_, err = gitService.FetchWithOptions(ctx, dir, models.GitFetchOptions{Remote: "mirror", Refspecs: []string{"copy:refs/remotes/mirror/copy"}}, nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, mustGit(t, dir, "rev-parse", "mirror/copy"))
+
+	mustGit(t, upstream, "branch", "-D", "gone")
+	_, err = gitService.FetchWithOptions(ctx, dir, models.GitFetchOptions{All: true, Prune: true}, nil)
+	require.NoError(t, err)
+	assert.NotContains(t, mustGit(t, dir, "branch", "-r"), "upstream/gone")
+	_, err = gitService.FetchWithOptions(ctx, dir, models.GitFetchOptions{All: true, Remote: "mirror"}, nil)
+	assert.Error(t, err)
+
+	// 在 upstream 上产生新提交后从 upstream 拉取
+	other := t.TempDir()
+	mustGit(t, other, "clone", "-q", upstream, ".")
+	writeTestFile(t, other, "new.txt", "new\n")
+	mustGit(t, other, "add", "new.txt")
+	mustGit(t, other, "commit", "-m", "upstream change")
+	mustGit(t, other, "push", "-q", "origin", "master")
+
+	_, err = gitService.PullWithOptions(ctx, dir, models.GitPullOptions{Remote: "upstream", Branch: "master"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "upstream change\n", mustGit(t, dir, "log", "-1", "--format=%s"))
+
+	_, err = gitService.PruneRemote(ctx, dir, "mirror")
+	require.NoError(t, err)
+}
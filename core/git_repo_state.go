@@ -0,0 +1,132 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"go-git-client-window/models"
+)
+
+// mergeMessagePattern 从 MERGE_MSG 中提取被合并的分支，例: Merge branch 'feature' into main
+var mergeMessagePattern = regexp.MustCompile(`^Merge (?:remote-tracking )?(?:branch|tag|commit) '([^']+)'`)
+
+// GetRepoState 检查 .git 目录，判断仓库是否处于 merge/rebase/cherry-pick/revert/bisect 过程中
+func (s *GitCoreService) GetRepoState(repoPath string) (*models.GitRepoState, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+
+	gitDir, err := resolveGitDir(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &models.GitRepoState{Operation: models.RepoOperationNone}
+	switch {
+	case fileExists(filepath.Join(gitDir, "rebase-merge")):
+		dir := filepath.Join(gitDir, "rebase-merge")
+		state.Operation = models.RepoOperationRebase
+		if fileExists(filepath.Join(dir, "interactive")) {
+			state.Operation = models.RepoOperationRebaseInteractive
+		}
+		state.Step = atoiDefault(readGitFile(dir, "msgnum"), 0)
+		state.Total = atoiDefault(readGitFile(dir, "end"), 0)
+		state.HeadName = strings.TrimPrefix(readGitFile(dir, "head-name"), "refs/heads/")
+		state.Onto = readGitFile(dir, "onto")
+		state.CurrentCommit = readGitFile(gitDir, "REBASE_HEAD")
+	case fileExists(filepath.Join(gitDir, "rebase-apply")):
+		dir := filepath.Join(gitDir, "rebase-apply")
+		state.Operation = models.RepoOperationRebase
+		if fileExists(filepath.Join(dir, "applying")) {
+			state.Operation = models.RepoOperationApplyMailbox
+		}
+		state.Step = atoiDefault(readGitFile(dir, "next"), 0)
+		state.Total = atoiDefault(readGitFile(dir, "last"), 0)
+		state.HeadName = strings.TrimPrefix(readGitFile(dir, "head-name"), "refs/heads/")
+		state.Onto = readGitFile(dir, "onto")
+		state.CurrentCommit = readGitFile(gitDir, "REBASE_HEAD")
+	case fileExists(filepath.Join(gitDir, "MERGE_HEAD")):
+		state.Operation = models.RepoOperationMerge
+		state.CurrentCommit = firstLine(readGitFile(gitDir, "MERGE_HEAD"))
+		if m := mergeMessagePattern.FindStringSubmatch(readGitFile(gitDir, "MERGE_MSG")); m != nil {
+			state.MergeBranch = m[1]
+		}
+	case fileExists(filepath.Join(gitDir, "CHERRY_PICK_HEAD")) || fileExists(filepath.Join(gitDir, "REVERT_HEAD")) ||
+		fileExists(filepath.Join(gitDir, "sequencer")):
+		sequencer, err := s.GetSequencerState(repoPath)
+		if err != nil {
+			return nil, err
+		}
+		if sequencer.InProgress {
+			state.Operation = sequencer.Operation
+			state.CurrentCommit = sequencer.CurrentCommit
+			state.Conflicts = sequencer.Conflicts
+		}
+	case fileExists(filepath.Join(gitDir, "BISECT_LOG")):
+		state.Operation = models.RepoOperationBisect
+		state.HeadName = readGitFile(gitDir, "BISECT_START")
+	}
+
+	if state.Operation == models.RepoOperationNone {
+		state.AllowedActions = []string{}
+		return state, nil
+	}
+
+	if state.HeadName == "" && state.Operation != models.RepoOperationBisect {
+		if branch, err := s.GetCurrentBranch(repoPath); err == nil {
+			state.HeadName = branch
+		}
+	}
+	if state.Onto != "" {
+		state.OntoName = s.branchAt(repoPath, state.Onto)
+	}
+	if state.Conflicts == nil {
+		state.Conflicts, err = s.GetMergeConflicts(repoPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	state.AllowedActions = allowedActions(state)
+
+	return state, nil
+}
+
+// allowedActions 根据操作类型与冲突情况计算可执行的动作
+func allowedActions(state *models.GitRepoState) []string {
+	actions := []string{}
+	resolved := len(state.Conflicts) == 0
+
+	switch state.Operation {
+	case models.RepoOperationMerge:
+		if resolved {
+			actions = append(actions, models.RepoActionContinue)
+		}
+		actions = append(actions, models.RepoActionAbort)
+	case models.RepoOperationBisect:
+		actions = append(actions, models.RepoActionSkip, models.RepoActionAbort)
+	default:
+		if resolved {
+			actions = append(actions, models.RepoActionContinue)
+		}
+		actions = append(actions, models.RepoActionSkip, models.RepoActionAbort)
+	}
+
+	return actions
+}
+
+// branchAt 返回指向指定提交的分支名，找不到时返回空字符串
+func (s *GitCoreService) branchAt(repoPath, commit string) string {
+	result, err := runGit(repoPath, "for-each-ref", "--points-at", commit, "--format=%(refname:short)", "refs/heads", "refs/remotes")
+	if err != nil {
+		return ""
+	}
+	return firstLine(result.Stdout)
+}
+
+// firstLine 返回第一行内容
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(line)
+}
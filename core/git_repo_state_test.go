@@ -0,0 +1,65 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-git-client-window/models"
+)
+
+func TestGetRepoState(t *testing.T) {
+	t.Run("无进行中操作", func(t *testing.T) {
+		dir := newTestRepo(t)
+		state, err := gitService.GetRepoState(dir)
+		require.NoError(t, err)
+		assert.Equal(t, models.RepoOperationNone, state.Operation)
+		assert.Empty(t, state.AllowedActions)
+	})
+
+	t.Run("合并冲突", func(t *testing.T) {
+		dir := newConflictRepo(t)
+		_, err := gitService.Merge(dir, "feature")
+		require.Error(t, err)
+
+		state, err := gitService.GetRepoState(dir)
+		require.NoError(t, err)
+		assert.Equal(t, models.RepoOperationMerge, state.Operation)
+		assert.Equal(t, "feature", state.MergeBranch)
+		assert.Equal(t, "master", state.HeadName)
+		assert.Equal(t, []string{"README.md"}, state.Conflicts)
+		assert.Equal(t, []string{models.RepoActionAbort}, state.AllowedActions)
+
+		mustGit(t, dir, "add", "README.md")
+		state, err = gitService.GetRepoState(dir)
+		require.NoError(t, err)
+		assert.Equal(t, []string{models.RepoActionContinue, models.RepoActionAbort}, state.AllowedActions)
+	})
+
+	t.Run("变基冲突", func(t *testing.T) {
+		dir := newConflictRepo(t)
+		mustGit(t, dir, "checkout", "feature")
+		_, err := gitService.Rebase(dir, "master")
+		require.Error(t, err)
+
+		state, err := gitService.GetRepoState(dir)
+		require.NoError(t, err)
+		assert.Contains(t, []string{models.RepoOperationRebase, models.RepoOperationRebaseInteractive}, state.Operation)
+		assert.Equal(t, 1, state.Step)
+		assert.Equal(t, 2, state.Total)
+		assert.Equal(t, "feature", state.HeadName)
+		assert.Equal(t, "master", state.OntoName)
+		assert.NotEmpty(t, state.CurrentCommit)
+		assert.Equal(t, []string{models.RepoActionSkip, models.RepoActionAbort}, state.AllowedActions)
+	})
+
+	t.Run("二分查找", func(t *testing.T) {
+		dir := newConflictRepo(t)
+		mustGit(t, dir, "bisect", "start")
+		state, err := gitService.GetRepoState(dir)
+		require.NoError(t, err)
+		assert.Equal(t, models.RepoOperationBisect, state.Operation)
+		assert.Equal(t, "master", state.HeadName)
+	})
+}
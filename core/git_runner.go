@@ -0,0 +1,162 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DefaultNetworkTimeout 网络类操作（clone/fetch/pull/push）的默认超时时间
+const DefaultNetworkTimeout = 30 * time.Minute
+
+// processWaitDelay 进程被取消后等待其输出管道关闭的最长时间
+const processWaitDelay = 5 * time.Second
+
+// GitCommandOptions 执行 Git 命令的参数
+type GitCommandOptions struct {
+	Dir          string        // 工作目录
+	Args         []string      // git 子命令及参数
+	Env          []string      // 追加的环境变量，格式 KEY=VALUE
+	Stdin        io.Reader     // 可选，标准输入
+	Stdout       io.Writer     // 可选，实时接收 stdout（如增量输出）
+	Stderr       io.Writer     // 可选，实时接收 stderr（如进度输出）
+	Timeout      time.Duration // 为 0 时不设置超时，仅受 ctx 控制
+	StreamStdout bool          // 为 true 且设置了 Stdout 时不缓存 stdout，结果中的 Stdout 为空，用于输出很大的命令
+}
+
+// GitCommandResult Git 命令执行结果
+type GitCommandResult struct {
+	Args     []string      `json:"args"`
+	ExitCode int           `json:"exitCode"`
+	Stdout   string        `json:"stdout"`
+	Stderr   string        `json:"stderr"`
+	Duration time.Duration `json:"duration"`
+}
+
+// CombinedOutput 返回 stdout 与 stderr 拼接后的输出
+func (r *GitCommandResult) CombinedOutput() string {
+	if r == nil {
+		return ""
+	}
+	return r.Stdout + r.Stderr
+}
+
+// RunGitCommand 在指定上下文中执行 Git 命令
+// ctx 被取消或超时时会终止整个进程组（包括 git 派生的 ssh、remote-https 等子进程）
+func RunGitCommand(ctx context.Context, opts GitCommandOptions) (*GitCommandResult, error) {
+	if strings.TrimSpace(opts.Dir) == "" {
+		return nil, fmt.Errorf("dir cannot be empty")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, "git", opts.Args...)
+	cmd.Dir = opts.Dir
+	// 固定英文输出，保证错误分类与输出解析不受系统语言影响
+	cmd.Env = append(os.Environ(), "LC_ALL=C")
+	cmd.Env = append(cmd.Env, credentialEnv()...)
+	cmd.Env = append(cmd.Env, opts.Env...)
+	cmd.Stdin = opts.Stdin
+	configureProcessGroup(cmd)
+	cmd.Cancel = func() error {
+		return killProcessGroup(cmd)
+	}
+	cmd.WaitDelay = processWaitDelay
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	if opts.Stdout != nil && opts.StreamStdout {
+		cmd.Stdout = opts.Stdout
+	} else if opts.Stdout != nil {
+		cmd.Stdout = io.MultiWriter(&stdout, opts.Stdout)
+	}
+	if opts.Stderr != nil {
+		cmd.Stderr = io.MultiWriter(&stderr, opts.Stderr)
+	} else {
+		cmd.Stderr = &stderr
+	}
+
+	start := time.Now()
+	err := cmd.Run()
+	result := &GitCommandResult{
+		Args:     opts.Args,
+		ExitCode: cmd.ProcessState.ExitCode(),
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
+		Duration: time.Since(start),
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return result, newGitError(opts.Args, result, ctxErr)
+	}
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			result.ExitCode = -1
+		}
+		return result, newGitError(opts.Args, result, err)
+	}
+
+	return result, nil
+}
+
+// ExecuteGitCommandContext 在指定上下文中执行 Git 命令，返回合并后的输出
+func ExecuteGitCommandContext(ctx context.Context, dir string, args ...string) (string, error) {
+	result, err := RunGitCommand(ctx, GitCommandOptions{Dir: dir, Args: args})
+	if err != nil {
+		return "", err
+	}
+	return result.CombinedOutput(), nil
+}
+
+// ExecuteGitCommand 执行 Git 命令
+func ExecuteGitCommand(dir string, args ...string) (string, error) {
+	return ExecuteGitCommandContext(context.Background(), dir, args...)
+}
+
+// runGit 执行 Git 命令并返回完整结果，用于需要单独解析 stdout 的场景
+func runGit(dir string, args ...string) (*GitCommandResult, error) {
+	return RunGitCommand(context.Background(), GitCommandOptions{Dir: dir, Args: args})
+}
+
+// lineWriter 将写入的数据按行切分后回调，用于实时解析命令输出
+type lineWriter struct {
+	onLine  func(line string)
+	pending []byte
+}
+
+// Write 实现 io.Writer
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.pending = append(w.pending, p...)
+	for {
+		i := bytes.IndexByte(w.pending, '\n')
+		if i < 0 {
+			break
+		}
+		w.onLine(string(w.pending[:i]))
+		w.pending = w.pending[i+1:]
+	}
+	return len(p), nil
+}
+
+// checkRevisionArgs 校验将作为位置参数传给 git 的引用，以 - 开头的值会被当作选项解析（如 --output=<file>）
+func checkRevisionArgs(refs []string) error {
+	for _, ref := range refs {
+		if strings.HasPrefix(ref, "-") {
+			return fmt.Errorf("invalid revision: %s", ref)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRepo 创建带有一次初始提交的临时仓库
+func newTestRepo(t *testing.T) string {
+	t.Helper()
+	t.Setenv("GIT_AUTHOR_NAME", "tester")
+	t.Setenv("GIT_AUTHOR_EMAIL", "tester@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "tester")
+	t.Setenv("GIT_COMMITTER_EMAIL", "tester@example.com")
+
+	dir := t.TempDir()
+	mustGit(t, dir, "init", "-b", "master")
+	writeTestFile(t, dir, "README.md", "hello\n")
+	mustGit(t, dir, "add", "README.md")
+	mustGit(t, dir, "commit", "-m", "Initial commit")
+	return dir
+}
+
+// mustGit 执行 git 命令，失败时终止测试
+func mustGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	result, err := RunGitCommand(context.Background(), GitCommandOptions{Dir: dir, Args: args})
+	require.NoError(t, err)
+	return result.Stdout
+}
+
+// writeTestFile 在仓库中写入文件
+func writeTestFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestRunGitCommand(t *testing.T) {
+	dir := newTestRepo(t)
+
+	t.Run("分离 stdout 与 stderr", func(t *testing.T) {
+		result, err := RunGitCommand(context.Background(), GitCommandOptions{
+			Dir:  dir,
+			Args: []string{"rev-parse", "--abbrev-ref", "HEAD"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.ExitCode)
+		assert.Equal(t, "master\n", result.Stdout)
+		assert.Empty(t, result.Stderr)
+		assert.Greater(t, result.Duration, time.Duration(0))
+	})
+
+	t.Run("返回退出码", func(t *testing.T) {
+		result, err := RunGitCommand(context.Background(), GitCommandOptions{
+			Dir:  dir,
+			Args: []string{"rev-parse", "--verify", "no-such-ref"},
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 128, result.ExitCode)
+		assert.NotEmpty(t, result.Stderr)
+	})
+
+	t.Run("仅流式输出 stdout", func(t *testing.T) {
+		var streamed bytes.Buffer
+		result, err := RunGitCommand(context.Background(), GitCommandOptions{
+			Dir:          dir,
+			Args:         []string{"rev-parse", "--abbrev-ref", "HEAD"},
+			Stdout:       &streamed,
+			StreamStdout: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "master\n", streamed.String())
+		assert.Empty(t, result.Stdout)
+	})
+
+	t.Run("空目录", func(t *testing.T) {
+		_, err := RunGitCommand(context.Background(), GitCommandOptions{Args: []string{"status"}})
+		assert.Error(t, err)
+	})
+}
+
+func TestRunGitCommandCancel(t *testing.T) {
+	dir := newTestRepo(t)
+
+	// 不关闭写端的 stdin 会让 hash-object 一直阻塞
+	stdin, writer, err := os.Pipe()
+	require.NoError(t, err)
+	defer stdin.Close()
+	defer writer.Close()
+
+	registry := NewOperationRegistry()
+	ctx, done := registry.Begin(context.Background(), "op-1", 0)
+	defer done()
+	assert.Equal(t, []string{"op-1"}, registry.Running())
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		_ = registry.Cancel("op-1")
+	}()
+
+	start := time.Now()
+	_, err = RunGitCommand(ctx, GitCommandOptions{
+		Dir:   dir,
+		Args:  []string{"hash-object", "--stdin"},
+		Stdin: stdin,
+	})
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.True(t, IsGitErrorKind(err, ErrKindCanceled))
+	assert.Less(t, time.Since(start), processWaitDelay)
+}
+
+func TestRunGitCommandTimeout(t *testing.T) {
+	dir := newTestRepo(t)
+
+	stdin, writer, err := os.Pipe()
+	require.NoError(t, err)
+	defer stdin.Close()
+	defer writer.Close()
+
+	_, err = RunGitCommand(context.Background(), GitCommandOptions{
+		Dir:     dir,
+		Args:    []string{"hash-object", "--stdin"},
+		Stdin:   stdin,
+		Timeout: 200 * time.Millisecond,
+	})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.True(t, IsGitErrorKind(err, ErrKindTimeout))
+}
@@ -0,0 +1,171 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go-git-client-window/models"
+)
+
+// CherryPick 将指定提交应用到当前分支
+func (s *GitCoreService) CherryPick(repoPath string, opts models.GitCherryPickOptions) (string, error) {
+	return s.runSequencer(repoPath, models.SequencerCherryPick, opts)
+}
+
+// Revert 撤销指定提交
+func (s *GitCoreService) Revert(repoPath string, opts models.GitCherryPickOptions) (string, error) {
+	opts.RecordOrigin = false
+	return s.runSequencer(repoPath, models.SequencerRevert, opts)
+}
+
+// runSequencer 执行 cherry-pick 或 revert
+func (s *GitCoreService) runSequencer(repoPath, operation string, opts models.GitCherryPickOptions) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+	if len(opts.Commits) == 0 {
+		return "", fmt.Errorf("commits cannot be empty")
+	}
+	if err := checkRevisionArgs(opts.Commits); err != nil {
+		return "", err
+	}
+
+	args := []string{operation}
+	if opts.RecordOrigin {
+		args = append(args, "-x")
+	}
+	if opts.NoCommit {
+		args = append(args, "--no-commit")
+	}
+	if opts.Mainline > 0 {
+		args = append(args, "--mainline", strconv.Itoa(opts.Mainline))
+	}
+	if operation == models.SequencerRevert {
+		// revert 默认会打开编辑器修改提交信息
+		args = append(args, "--no-edit")
+	}
+	args = append(args, opts.Commits...)
+
+	output, err := ExecuteGitCommand(repoPath, args...)
+	if err != nil {
+		return "", err
+	}
+
+	return output, nil
+}
+
+// GetSequencerState 获取进行中的 cherry-pick / revert 状态
+func (s *GitCoreService) GetSequencerState(repoPath string) (*models.GitSequencerState, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+
+	gitDir, err := resolveGitDir(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &models.GitSequencerState{}
+	switch {
+	case fileExists(filepath.Join(gitDir, "CHERRY_PICK_HEAD")):
+		state.Operation = models.SequencerCherryPick
+		state.CurrentCommit = readGitFile(gitDir, "CHERRY_PICK_HEAD")
+	case fileExists(filepath.Join(gitDir, "REVERT_HEAD")):
+		state.Operation = models.SequencerRevert
+		state.CurrentCommit = readGitFile(gitDir, "REVERT_HEAD")
+	}
+
+	// 多个提交的操作在冲突解决并提交后，只剩 sequencer 目录
+	todo := readGitFile(gitDir, filepath.Join("sequencer", "todo"))
+	for _, line := range strings.Split(todo, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		state.Remaining = append(state.Remaining, line)
+		if state.Operation == "" {
+			if strings.HasPrefix(line, "revert ") {
+				state.Operation = models.SequencerRevert
+			} else {
+				state.Operation = models.SequencerCherryPick
+			}
+		}
+	}
+	state.InProgress = state.Operation != ""
+
+	if state.InProgress {
+		state.Conflicts, err = s.GetMergeConflicts(repoPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return state, nil
+}
+
+// SequencerContinue 解决冲突后继续进行中的 cherry-pick / revert
+func (s *GitCoreService) SequencerContinue(repoPath string) (string, error) {
+	return s.controlSequencer(repoPath, "--continue")
+}
+
+// SequencerSkip 跳过当前提交
+func (s *GitCoreService) SequencerSkip(repoPath string) (string, error) {
+	return s.controlSequencer(repoPath, "--skip")
+}
+
+// SequencerAbort 放弃进行中的 cherry-pick / revert，恢复到操作前的状态
+func (s *GitCoreService) SequencerAbort(repoPath string) (string, error) {
+	return s.controlSequencer(repoPath, "--abort")
+}
+
+// controlSequencer 对进行中的操作执行 --continue/--skip/--abort
+func (s *GitCoreService) controlSequencer(repoPath, action string) (string, error) {
+	state, err := s.GetSequencerState(repoPath)
+	if err != nil {
+		return "", err
+	}
+	if !state.InProgress {
+		return "", fmt.Errorf("no cherry-pick or revert in progress")
+	}
+
+	// --continue 提交时沿用 git 准备好的提交信息，不打开编辑器
+	result, err := RunGitCommand(context.Background(), GitCommandOptions{
+		Dir:  repoPath,
+		Args: []string{state.Operation, action},
+		Env:  []string{"GIT_EDITOR=true"},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return result.CombinedOutput(), nil
+}
+
+// resolveGitDir 获取仓库（或当前工作树）的 .git 目录绝对路径
+func resolveGitDir(repoPath string) (string, error) {
+	result, err := runGit(repoPath, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(result.Stdout), nil
+}
+
+// readGitFile 读取 .git 目录下的文件内容，不存在时返回空字符串
+func readGitFile(gitDir, name string) string {
+	data, err := os.ReadFile(filepath.Join(gitDir, name))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// fileExists 判断文件或目录是否存在
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return !errors.Is(err, os.ErrNotExist)
+}
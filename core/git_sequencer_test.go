@@ -0,0 +1,103 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-git-client-window/models"
+)
+
+// newConflictRepo 创建 feature 分支与 master 在 README.md 上冲突的仓库，当前位于 master
+// feature 上有两个提交：冲突的修改 README.md，以及不冲突的新增 feature.txt
+func newConflictRepo(t *testing.T) string {
+	t.Helper()
+	dir := newTestRepo(t)
+
+	mustGit(t, dir, "checkout", "-b", "feature")
+	writeTestFile(t, dir, "README.md", "feature\n")
+	mustGit(t, dir, "commit", "-am", "feature readme")
+	writeTestFile(t, dir, "feature.txt", "feature\n")
+	mustGit(t, dir, "add", "feature.txt")
+	mustGit(t, dir, "commit", "-m", "feature file")
+
+	mustGit(t, dir, "checkout", "master")
+	writeTestFile(t, dir, "README.md", "master\n")
+	mustGit(t, dir, "commit", "-am", "master readme")
+	return dir
+}
+
+func TestCherryPick(t *testing.T) {
+	t.Run("无冲突并记录来源", func(t *testing.T) {
+		dir := newConflictRepo(t)
+		_, err := gitService.CherryPick(dir, models.GitCherryPickOptions{Commits: []string{"feature"}, RecordOrigin: true})
+		require.NoError(t, err)
+		message := mustGit(t, dir, "log", "-1", "--format=%B")
+		assert.Contains(t, message, "cherry picked from commit")
+	})
+
+	t.Run("拒绝选项形式的提交", func(t *testing.T) {
+		dir := newConflictRepo(t)
+		_, err := gitService.CherryPick(dir, models.GitCherryPickOptions{Commits: []string{"--quit"}})
+		assert.ErrorContains(t, err, "invalid revision")
+		_, err = gitService.Revert(dir, models.GitCherryPickOptions{Commits: []string{"feature", "--abort"}})
+		assert.ErrorContains(t, err, "invalid revision")
+	})
+
+	t.Run("冲突后跳过并继续", func(t *testing.T) {
+		dir := newConflictRepo(t)
+		_, err := gitService.CherryPick(dir, models.GitCherryPickOptions{Commits: []string{"master..feature"}})
+		assert.True(t, IsGitErrorKind(err, ErrKindConflict))
+
+		state, err := gitService.GetSequencerState(dir)
+		require.NoError(t, err)
+		assert.True(t, state.InProgress)
+		assert.Equal(t, models.SequencerCherryPick, state.Operation)
+		assert.Equal(t, strings.TrimSpace(mustGit(t, dir, "rev-parse", "feature~1")), state.CurrentCommit)
+		assert.Equal(t, []string{"README.md"}, state.Conflicts)
+		require.NotEmpty(t, state.Remaining)
+
+		_, err = gitService.SequencerSkip(dir)
+		require.NoError(t, err)
+
+		state, err = gitService.GetSequencerState(dir)
+		require.NoError(t, err)
+		assert.False(t, state.InProgress)
+		assert.Equal(t, "feature file\n", mustGit(t, dir, "log", "-1", "--format=%s"))
+	})
+
+	t.Run("解决冲突后继续", func(t *testing.T) {
+		dir := newConflictRepo(t)
+		_, err := gitService.CherryPick(dir, models.GitCherryPickOptions{Commits: []string{"feature~1"}})
+		require.Error(t, err)
+
+		writeTestFile(t, dir, "README.md", "resolved\n")
+		mustGit(t, dir, "add", "README.md")
+		_, err = gitService.SequencerContinue(dir)
+		require.NoError(t, err)
+		assert.Equal(t, "feature readme\n", mustGit(t, dir, "log", "-1", "--format=%s"))
+	})
+}
+
+func TestRevertAbort(t *testing.T) {
+	dir := newConflictRepo(t)
+	mustGit(t, dir, "merge", "--no-ff", "-s", "ours", "-m", "merge feature", "feature")
+
+	_, err := gitService.Revert(dir, models.GitCherryPickOptions{Commits: []string{"HEAD"}, Mainline: 1, NoCommit: true})
+	require.NoError(t, err)
+
+	state, err := gitService.GetSequencerState(dir)
+	require.NoError(t, err)
+	assert.Equal(t, models.SequencerRevert, state.Operation)
+
+	_, err = gitService.SequencerAbort(dir)
+	require.NoError(t, err)
+	state, err = gitService.GetSequencerState(dir)
+	require.NoError(t, err)
+	assert.False(t, state.InProgress)
+
+	_, err = gitService.SequencerAbort(dir)
+	assert.Error(t, err)
+}
@@ -1,11 +1,14 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
-	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"go-git-client-window/models"
 )
@@ -13,49 +16,30 @@ import (
 var logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
 
 // GitCoreService Git核心服务
-type GitCoreService struct{}
+type GitCoreService struct {
+	operations *OperationRegistry
+}
 
 // NewGitCoreService 创建新的Git核心服务
 func NewGitCoreService() *GitCoreService {
-	return &GitCoreService{}
-}
-
-// ExecuteGitCommand 执行 Git 命令
-func ExecuteGitCommand(dir string, args ...string) (string, error) {
-	if strings.TrimSpace(dir) == "" {
-		return "", fmt.Errorf("dir cannot be empty")
-	}
-	cmd := exec.Command("git", args...)
-	cmd.Dir = dir
-
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return "", fmt.Errorf("%v, %v", err.Error(), string(output))
+	return &GitCoreService{
+		operations: NewOperationRegistry(),
 	}
-
-	return string(output), nil
 }
 
-// ParseBranchLine 解析分支行
-func ParseBranchLine(branchLine string) (name string, isCurrent, isRemote bool) {
-	line := strings.TrimSpace(branchLine)
-	isCurrent = strings.HasPrefix(line, "* ")
-
-	// 移除当前分支标记
-	cleanLine := strings.TrimPrefix(line, "* ")
-
-	// 检查是否为远程分支
-	isRemote = strings.Contains(cleanLine, "remotes/")
-
-	// 提取分支名称
-	name = strings.TrimSpace(cleanLine)
+// BeginOperation 注册一个可取消的操作，返回的 done 必须在操作结束后调用
+func (s *GitCoreService) BeginOperation(parent context.Context, operationID string, timeout time.Duration) (context.Context, func()) {
+	return s.operations.Begin(parent, operationID, timeout)
+}
 
-	// 如果是远程分支，进一步清理
-	if isRemote {
-		name = strings.TrimPrefix(name, "remotes/")
-	}
+// CancelOperation 取消正在执行的操作
+func (s *GitCoreService) CancelOperation(operationID string) error {
+	return s.operations.Cancel(operationID)
+}
 
-	return name, isCurrent, isRemote
+// RunningOperations 获取正在执行的操作 ID 列表
+func (s *GitCoreService) RunningOperations() []string {
+	return s.operations.Running()
 }
 
 // ParseCommitLine 解析提交行 (格式: hash\x1frefs\x1fmessage\x1fauthor\x1fdate)
@@ -71,17 +55,7 @@ func ParseCommitLine(commitLine string) (*models.GitCommitRecord, error) {
 	author := strings.TrimSpace(parts[3])
 	date := strings.TrimSpace(parts[4])
 
-	var branches []string
-	if refs != "" {
-		refsParts := strings.Split(refs, ",")
-		branches = make([]string, 0, len(refsParts)) // 预分配容量提高性能
-		for _, ref := range refsParts {
-			branch := strings.TrimSpace(strings.Trim(ref, "()"))
-			if branch != "" { // 过滤空字符串
-				branches = append(branches, branch)
-			}
-		}
-	}
+	branches, tags := ParseRefNames(refs)
 
 	commit := models.GitCommitRecord{
 		Hash:     hash,
@@ -89,74 +63,50 @@ func ParseCommitLine(commitLine string) (*models.GitCommitRecord, error) {
 		Author:   author,
 		Date:     date,
 		Branches: branches,
+		Tags:     tags,
 	}
 	return &commit, nil
 }
 
-// GetBranches 获取所有分支（本地和远程）
-func (s *GitCoreService) GetBranches(repoPath string) ([]models.GitBranch, error) {
-	if strings.TrimSpace(repoPath) == "" {
-		return nil, fmt.Errorf("path cannot be empty")
-	}
-
-	output, err := ExecuteGitCommand(repoPath, "branch", "-a")
-	if err != nil {
-		return nil, err
+// ParseRefNames 解析 %D 格式的引用装饰，将标签与分支分开
+func ParseRefNames(refs string) (branches []string, tags []string) {
+	if refs == "" {
+		return nil, nil
 	}
 
-	var branches []models.GitBranch
-	lines := strings.Split(output, "\n")
-
-	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
+	refsParts := strings.Split(refs, ",")
+	branches = make([]string, 0, len(refsParts)) // 预分配容量提高性能
+	for _, ref := range refsParts {
+		name := strings.TrimSpace(strings.Trim(ref, "()"))
+		if name == "" { // 过滤空字符串
 			continue
 		}
-
-		name, isCurrent, isRemote := ParseBranchLine(line)
-		if name != "" {
-			branch := models.GitBranch{
-				Name:    name,
-				Current: isCurrent,
-				Remote:  isRemote,
-			}
-			branches = append(branches, branch)
+		if tag, ok := strings.CutPrefix(name, "tag: "); ok {
+			tags = append(tags, tag)
+			continue
 		}
+		branches = append(branches, name)
 	}
 
-	return branches, nil
+	return branches, tags
 }
 
-// GetLocalBranches 获取本地分支
-func (s *GitCoreService) GetLocalBranches(repoPath string) ([]models.GitBranch, error) {
+// GetBranches 获取所有分支（本地和远程）
+func (s *GitCoreService) GetBranches(repoPath string) ([]models.GitBranch, error) {
 	if strings.TrimSpace(repoPath) == "" {
 		return nil, fmt.Errorf("path cannot be empty")
 	}
 
-	output, err := ExecuteGitCommand(repoPath, "branch", "--list")
-	if err != nil {
-		return nil, err
-	}
-
-	var branches []models.GitBranch
-	lines := strings.Split(output, "\n")
-
-	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
+	return listBranches(repoPath, "refs/heads", "refs/remotes")
+}
 
-		name, isCurrent, _ := ParseBranchLine(line)
-		if name != "" {
-			branch := models.GitBranch{
-				Name:    name,
-				Current: isCurrent,
-				Remote:  false,
-			}
-			branches = append(branches, branch)
-		}
+// GetLocalBranches 获取本地分支
+func (s *GitCoreService) GetLocalBranches(repoPath string) ([]models.GitBranch, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
 	}
 
-	return branches, nil
+	return listBranches(repoPath, "refs/heads")
 }
 
 // GetRemoteBranches 获取远程分支
@@ -165,31 +115,7 @@ func (s *GitCoreService) GetRemoteBranches(repoPath string) ([]models.GitBranch,
 		return nil, fmt.Errorf("path cannot be empty")
 	}
 
-	output, err := ExecuteGitCommand(repoPath, "branch", "-r")
-	if err != nil {
-		return nil, err
-	}
-
-	var branches []models.GitBranch
-	lines := strings.Split(output, "\n")
-
-	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		name, _, isRemote := ParseBranchLine(line)
-		if name != "" && isRemote {
-			branch := models.GitBranch{
-				Name:    name,
-				Current: false,
-				Remote:  isRemote,
-			}
-			branches = append(branches, branch)
-		}
-	}
-
-	return branches, nil
+	return listBranches(repoPath, "refs/remotes")
 }
 
 // GetBranchLog 获取分支提交日志
@@ -232,7 +158,51 @@ func (s *GitCoreService) GetChangedFiles(repoPath string) ([]models.GitFileStatu
 
 // GetStagedFiles 获取已暂存文件
 func (s *GitCoreService) GetStagedFiles(repoPath string) ([]models.GitFileStatus, error) {
-	return s.GetStagedFiles(repoPath)
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+
+	result, err := runGit(repoPath, "diff", "--cached", "--name-status", "-z", "--find-renames")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseNameStatus(result.Stdout)
+}
+
+// ParseNameStatus 解析 diff --name-status -z 输出，重命名/复制记录后跟旧路径与新路径
+func ParseNameStatus(output string) ([]models.GitFileStatus, error) {
+	files := []models.GitFileStatus{}
+	fields := strings.Split(output, "\x00")
+	for i := 0; i < len(fields); i++ {
+		status := fields[i]
+		if status == "" {
+			continue
+		}
+		if i+1 >= len(fields) {
+			return nil, fmt.Errorf("invalid name-status record: %s", status)
+		}
+
+		file := models.GitFileStatus{
+			Status:      status[:1],
+			IndexStatus: status[:1],
+			Staged:      true,
+			Unmerged:    status[:1] == "U",
+		}
+		file.Score, _ = strconv.Atoi(status[1:])
+		i++
+		file.Filename = fields[i]
+		if file.Status == "R" || file.Status == "C" {
+			if i+1 >= len(fields) {
+				return nil, fmt.Errorf("invalid name-status record: %s", status)
+			}
+			i++
+			file.OrigFilename, file.Filename = file.Filename, fields[i]
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
 }
 
 // GetRemotes 获取远程仓库信息
@@ -317,7 +287,8 @@ func (s *GitCoreService) UnstageFile(repoPath, filename string) (string, error)
 		return "", fmt.Errorf("path cannot be empty")
 	}
 
-	output, err := ExecuteGitCommand(repoPath, "reset", "HEAD", "--", filename)
+	// 不指定 HEAD，尚无提交的仓库同样可以取消暂存
+	output, err := ExecuteGitCommand(repoPath, "reset", "--quiet", "--", filename)
 	if err != nil {
 		return "", err
 	}
@@ -325,66 +296,32 @@ func (s *GitCoreService) UnstageFile(repoPath, filename string) (string, error)
 	return output, nil
 }
 
-// Push 推送到远程
-func (s *GitCoreService) Push(repoPath, branch string, force bool) (string, error) {
-	if strings.TrimSpace(repoPath) == "" {
-		return "", fmt.Errorf("path cannot be empty")
-	}
-
-	args := []string{"push"}
-	if force {
-		args = append(args, "--force")
-	}
-	args = append(args, "origin", branch)
-
-	output, err := ExecuteGitCommand(repoPath, args...)
-	if err != nil {
-		return "", err
+// Push 推送到远程，强制推送请使用 PushWithOptions
+func (s *GitCoreService) Push(ctx context.Context, repoPath, branch string, onProgress ProgressFunc) (string, error) {
+	var opts models.GitPushOptions
+	if branch != "" {
+		opts.Refspecs = []string{branch}
 	}
-
-	return output, nil
+	return s.PushWithOptions(ctx, repoPath, opts, onProgress)
 }
 
-// Pull 从远程拉取
-func (s *GitCoreService) Pull(repoPath, branch string) (string, error) {
-	if strings.TrimSpace(repoPath) == "" {
-		return "", fmt.Errorf("path cannot be empty")
-	}
-
-	output, err := ExecuteGitCommand(repoPath, "pull", "origin", branch)
+// Pull 从远程拉取，未指定分支时从上游拉取
+func (s *GitCoreService) Pull(ctx context.Context, repoPath, branch string, onProgress ProgressFunc) (string, error) {
+	result, err := s.PullWithOptions(ctx, repoPath, models.GitPullOptions{Branch: branch}, onProgress)
 	if err != nil {
 		return "", err
 	}
-
-	return output, nil
+	return result.Output, nil
 }
 
 // Fetch 获取远程更新
-func (s *GitCoreService) Fetch(repoPath string) (string, error) {
-	if strings.TrimSpace(repoPath) == "" {
-		return "", fmt.Errorf("path cannot be empty")
-	}
-
-	output, err := ExecuteGitCommand(repoPath, "fetch")
-	if err != nil {
-		return "", err
-	}
-
-	return output, nil
+func (s *GitCoreService) Fetch(ctx context.Context, repoPath string, onProgress ProgressFunc) (string, error) {
+	return s.FetchWithOptions(ctx, repoPath, models.GitFetchOptions{}, onProgress)
 }
 
 // Merge 合并分支
 func (s *GitCoreService) Merge(repoPath, branch string) (string, error) {
-	if strings.TrimSpace(repoPath) == "" {
-		return "", fmt.Errorf("path cannot be empty")
-	}
-
-	output, err := ExecuteGitCommand(repoPath, "merge", branch)
-	if err != nil {
-		return "", err
-	}
-
-	return output, nil
+	return s.MergeWithOptions(repoPath, models.GitMergeOptions{Branch: branch})
 }
 
 // Init 初始化仓库
@@ -416,18 +353,23 @@ func (s *GitCoreService) Status(repoPath string) (string, error) {
 }
 
 // Clone 克隆仓库
-func (s *GitCoreService) Clone(repoURL, targetPath string) (string, error) {
+func (s *GitCoreService) Clone(ctx context.Context, repoURL, targetPath string, onProgress ProgressFunc) (string, error) {
 	if strings.TrimSpace(repoURL) == "" || strings.TrimSpace(targetPath) == "" {
 		return "", fmt.Errorf("repo URL and target path cannot be empty")
 	}
 
-	cmd := exec.Command("git", "clone", repoURL, targetPath)
-	output, err := cmd.CombinedOutput()
+	absTarget, err := filepath.Abs(targetPath)
+	if err != nil {
+		return "", err
+	}
+
+	// 目标目录可能尚不存在，因此在其父目录中执行 clone
+	result, err := runWithProgress(ctx, filepath.Dir(absTarget), onProgress, "clone", "--progress", repoURL, absTarget)
 	if err != nil {
-		return "", fmt.Errorf("git clone failed: %s, output: %s", err.Error(), string(output))
+		return "", fmt.Errorf("git clone failed: %w", err)
 	}
 
-	return string(output), nil
+	return progressOutput(result), nil
 }
 
 // Add 添加文件到暂存区
@@ -495,26 +437,12 @@ func (s *GitCoreService) GetFileDiff(repoPath, filename string, staged bool) ([]
 
 	var args []string
 	if staged {
-		args = []string{"diff", "--cached", "--", filename}
+		args = []string{"--cached", "--", filename}
 	} else {
-		args = []string{"diff", "--", filename}
-	}
-
-	output, err := ExecuteGitCommand(repoPath, args...)
-	if err != nil {
-		return nil, err
-	}
-
-	lines := strings.Split(output, "\n")
-	var diffs []models.FileDiff
-
-	for _, line := range lines {
-		if strings.TrimSpace(line) != "" {
-			diffs = append(diffs, models.FileDiff{Changes: []string{line}})
-		}
+		args = []string{"--", filename}
 	}
 
-	return diffs, nil
+	return runDiff(repoPath, []string{"diff"}, args...)
 }
 
 // GetGraphHistory 获取图形化历史数据
@@ -560,6 +488,29 @@ func (s *GitCoreService) ResetFile(repoPath, filename string) (string, error) {
 	return output, nil
 }
 
+// CheckoutFile 丢弃工作区中文件的改动，恢复为暂存区中的版本
+func (s *GitCoreService) CheckoutFile(repoPath, filename string) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+
+	output, err := ExecuteGitCommand(repoPath, "checkout", "--", filename)
+	if err != nil {
+		return "", err
+	}
+
+	return output, nil
+}
+
+// GetLog 获取当前分支最近 limit 条提交日志
+func (s *GitCoreService) GetLog(repoPath string, limit int) ([]models.GitLogEntry, error) {
+	page, err := s.QueryLog(repoPath, models.GitLogQuery{Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	return page.Commits, nil
+}
+
 // GetMergeConflicts 获取合并冲突列表
 func (s *GitCoreService) GetMergeConflicts(repoPath string) ([]string, error) {
 	if strings.TrimSpace(repoPath) == "" {
@@ -607,25 +558,6 @@ func (s *GitCoreService) ResolveConflict(repoPath, filename, strategy string) (s
 	return output, nil
 }
 
-// StashSave 保存 stash
-func (s *GitCoreService) StashSave(repoPath, message string) (string, error) {
-	if strings.TrimSpace(repoPath) == "" {
-		return "", fmt.Errorf("path cannot be empty")
-	}
-
-	args := []string{"stash", "save"}
-	if message != "" {
-		args = append(args, message)
-	}
-
-	output, err := ExecuteGitCommand(repoPath, args...)
-	if err != nil {
-		return "", err
-	}
-
-	return output, nil
-}
-
 // StashApply 应用 stash
 func (s *GitCoreService) StashApply(repoPath, stashId string) (string, error) {
 	if strings.TrimSpace(repoPath) == "" {
@@ -668,29 +600,6 @@ func (s *GitCoreService) StashDrop(repoPath, stashId string) (string, error) {
 	return output, nil
 }
 
-// GetBlame 获取文件 blame 信息
-func (s *GitCoreService) GetBlame(repoPath, filename string) ([]models.GitBlameLine, error) {
-	if strings.TrimSpace(repoPath) == "" {
-		return nil, fmt.Errorf("path cannot be empty")
-	}
-
-	output, err := ExecuteGitCommand(repoPath, "blame", "-p", filename)
-	if err != nil {
-		return nil, err
-	}
-
-	lines := strings.Split(output, "\n")
-	var blameLines []models.GitBlameLine
-
-	for _, line := range lines {
-		if strings.TrimSpace(line) != "" {
-			blameLines = append(blameLines, models.GitBlameLine{Content: line})
-		}
-	}
-
-	return blameLines, nil
-}
-
 // AmendCommit 修改最后一次提交
 func (s *GitCoreService) AmendCommit(repoPath, message string) (string, error) {
 	if strings.TrimSpace(repoPath) == "" {
@@ -721,69 +630,12 @@ func (s *GitCoreService) Checkout(repoPath, branch string) (string, error) {
 
 // GetStatusStructured 获取 Git 状态（结构化返回）
 func (s *GitCoreService) GetStatusStructured(repoPath string) ([]models.GitFileStatus, error) {
-	if strings.TrimSpace(repoPath) == "" {
-		return nil, fmt.Errorf("path cannot be empty")
-	}
-
-	output, err := ExecuteGitCommand(repoPath, "status", "--porcelain", "-uall")
-	if err != nil {
-		return nil, err
-	}
-
-	lines := strings.Split(output, "\n")
-	var files []models.GitFileStatus
-
-	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		fields := strings.Fields(line)
-		if len(fields) >= 2 {
-			status := fields[0]
-			filename := strings.Join(fields[1:], " ")
-			files = append(files, models.GitFileStatus{
-				Filename: filename,
-				Status:   status,
-			})
-		}
-	}
-
-	return files, nil
-}
-
-// GetRemoteInfo 获取远程仓库信息
-func (s *GitCoreService) GetRemoteInfo(repoPath string) ([]models.GitRemoteInfo, error) {
-	if strings.TrimSpace(repoPath) == "" {
-		return nil, fmt.Errorf("path cannot be empty")
-	}
-
-	output, err := ExecuteGitCommand(repoPath, "remote", "-v")
+	status, err := s.GetRepoStatus(repoPath)
 	if err != nil {
 		return nil, err
 	}
 
-	lines := strings.Split(output, "\n")
-	var remotes []models.GitRemoteInfo
-
-	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		fields := strings.Fields(line)
-		if len(fields) >= 2 {
-			name := fields[0]
-			url := strings.Trim(fields[1], "()")
-			remote := models.GitRemoteInfo{
-				Name: name,
-				URL:  url,
-			}
-			remotes = append(remotes, remote)
-		}
-	}
-
-	return remotes, nil
+	return status.Files, nil
 }
 
 // Rebase 变基操作
@@ -800,34 +652,6 @@ func (s *GitCoreService) Rebase(repoPath, branch string) (string, error) {
 	return output, nil
 }
 
-// GetStashList 获取 stash 列表
-func (s *GitCoreService) GetStashList(repoPath string) ([]models.GitStash, error) {
-	if strings.TrimSpace(repoPath) == "" {
-		return nil, fmt.Errorf("path cannot be empty")
-	}
-
-	output, err := ExecuteGitCommand(repoPath, "stash", "list")
-	if err != nil {
-		return nil, err
-	}
-
-	lines := strings.Split(output, "\n")
-	var stashes []models.GitStash
-
-	for i, line := range lines {
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		stashes = append(stashes, models.GitStash{
-			ID:      fmt.Sprintf("stash@{%d}", i),
-			Message: line,
-		})
-	}
-
-	return stashes, nil
-}
-
 // GetGraphHistoryWithFormat 获取图形化历史数据
 func (s *GitCoreService) GetGraphHistoryWithFormat(repoPath string, limit int) (string, error) {
 	if strings.TrimSpace(repoPath) == "" {
@@ -0,0 +1,143 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go-git-client-window/models"
+)
+
+// stashSubjectPattern 例: "WIP on main: abc1234 message" 或 "On main: message"
+var stashSubjectPattern = regexp.MustCompile(`^(?:WIP on|On) ([^:]+): (.*)$`)
+
+// stashIndexPattern 例: stash@{3}
+var stashIndexPattern = regexp.MustCompile(`^stash@\{(\d+)\}$`)
+
+// GetStashList 获取 stash 列表
+func (s *GitCoreService) GetStashList(repoPath string) ([]models.GitStash, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+
+	result, err := runGit(repoPath, "stash", "list", "--format=%gd\x1f%H\x1f%ci\x1f%gs")
+	if err != nil {
+		return nil, err
+	}
+
+	stashes := []models.GitStash{}
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		stash, err := ParseStashLine(line)
+		if err != nil {
+			logger.Error("parse stash line error", "error", err)
+			continue
+		}
+		stashes = append(stashes, *stash)
+	}
+
+	return stashes, nil
+}
+
+// ParseStashLine 解析 stash 行 (格式: selector\x1fhash\x1fdate\x1fsubject)
+func ParseStashLine(line string) (*models.GitStash, error) {
+	parts := strings.SplitN(line, "\x1f", 4)
+	if len(parts) < 4 {
+		return nil, fmt.Errorf("invalid stash line format: %s", line)
+	}
+
+	stash := &models.GitStash{
+		ID:      parts[0],
+		Hash:    parts[1],
+		Date:    parts[2],
+		Message: parts[3],
+	}
+	if m := stashIndexPattern.FindStringSubmatch(stash.ID); m != nil {
+		stash.Index, _ = strconv.Atoi(m[1])
+	}
+	if m := stashSubjectPattern.FindStringSubmatch(parts[3]); m != nil {
+		stash.Branch = m[1]
+		stash.Message = m[2]
+	}
+
+	return stash, nil
+}
+
+// StashSave 保存 stash
+func (s *GitCoreService) StashSave(repoPath, message string) (string, error) {
+	return s.StashPush(repoPath, models.GitStashPushOptions{Message: message})
+}
+
+// StashPush 使用 git stash push 保存 stash，支持指定路径
+func (s *GitCoreService) StashPush(repoPath string, opts models.GitStashPushOptions) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+
+	args := []string{"stash", "push"}
+	if opts.Message != "" {
+		args = append(args, "--message", opts.Message)
+	}
+	if opts.KeepIndex {
+		args = append(args, "--keep-index")
+	}
+	if opts.IncludeUntracked {
+		args = append(args, "--include-untracked")
+	}
+	if opts.Staged {
+		args = append(args, "--staged")
+	}
+	if len(opts.Paths) > 0 {
+		args = append(args, "--")
+		args = append(args, opts.Paths...)
+	}
+
+	output, err := ExecuteGitCommand(repoPath, args...)
+	if err != nil {
+		return "", err
+	}
+
+	return output, nil
+}
+
+// StashShow 获取 stash 的结构化差异，includeUntracked 为 true 时包含 stash 中的未跟踪文件
+func (s *GitCoreService) StashShow(repoPath, stashId string, includeUntracked bool) ([]models.FileDiff, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+
+	var args []string
+	if includeUntracked {
+		args = append(args, "--include-untracked")
+	}
+	if stashId != "" {
+		args = append(args, stashId)
+	}
+
+	return runDiff(repoPath, []string{"stash", "show", "--patch"}, args...)
+}
+
+// StashBranch 基于 stash 创建分支并应用，成功后删除该 stash
+func (s *GitCoreService) StashBranch(repoPath, branchName, stashId string) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(branchName) == "" {
+		return "", fmt.Errorf("branch name cannot be empty")
+	}
+
+	args := []string{"stash", "branch", branchName}
+	if stashId != "" {
+		args = append(args, stashId)
+	}
+
+	output, err := ExecuteGitCommand(repoPath, args...)
+	if err != nil {
+		return "", err
+	}
+
+	return output, nil
+}
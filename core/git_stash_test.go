@@ -0,0 +1,66 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-git-client-window/models"
+)
+
+func TestParseStashLine(t *testing.T) {
+	stash, err := ParseStashLine("stash@{2}\x1fabc123\x1f2024-01-02 03:04:05 +0800\x1fWIP on feature/x: 1234567 fix: a thing")
+	require.NoError(t, err)
+	assert.Equal(t, models.GitStash{
+		ID:      "stash@{2}",
+		Index:   2,
+		Hash:    "abc123",
+		Message: "1234567 fix: a thing",
+		Branch:  "feature/x",
+		Date:    "2024-01-02 03:04:05 +0800",
+	}, *stash)
+
+	stash, err = ParseStashLine("stash@{0}\x1fdef456\x1f2024-01-02 03:04:05 +0800\x1fOn main: my message")
+	require.NoError(t, err)
+	assert.Equal(t, "main", stash.Branch)
+	assert.Equal(t, "my message", stash.Message)
+
+	_, err = ParseStashLine("garbage")
+	assert.Error(t, err)
+}
+
+func TestStashLifecycle(t *testing.T) {
+	dir := newTestRepo(t)
+	writeTestFile(t, dir, "README.md", "changed\n")
+	writeTestFile(t, dir, "other.txt", "untracked\n")
+
+	_, err := gitService.StashPush(dir, models.GitStashPushOptions{Message: "first", Paths: []string{"README.md"}})
+	require.NoError(t, err)
+	_, err = gitService.StashPush(dir, models.GitStashPushOptions{Message: "second", IncludeUntracked: true})
+	require.NoError(t, err)
+
+	stashes, err := gitService.GetStashList(dir)
+	require.NoError(t, err)
+	require.Len(t, stashes, 2)
+	assert.Equal(t, "stash@{0}", stashes[0].ID)
+	assert.Equal(t, "second", stashes[0].Message)
+	assert.Equal(t, 1, stashes[1].Index)
+	assert.Equal(t, "master", stashes[1].Branch)
+	assert.NotEmpty(t, stashes[1].Date)
+
+	diffs, err := gitService.StashShow(dir, "stash@{1}", false)
+	require.NoError(t, err)
+	require.Len(t, diffs, 1)
+	assert.Equal(t, "README.md", diffs[0].Filename)
+
+	_, err = gitService.StashBranch(dir, "from-stash", "stash@{1}")
+	require.NoError(t, err)
+	branch, err := gitService.GetCurrentBranch(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "from-stash", branch)
+
+	stashes, err = gitService.GetStashList(dir)
+	require.NoError(t, err)
+	assert.Len(t, stashes, 1)
+}
@@ -0,0 +1,135 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go-git-client-window/models"
+)
+
+// GetRepoStatus 获取仓库状态，包括分支头信息和暂存区/工作区的变更
+func (s *GitCoreService) GetRepoStatus(repoPath string) (*models.GitStatus, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+
+	result, err := runGit(repoPath, "status", "--porcelain=v2", "-z", "--branch", "-uall")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePorcelainV2Status(result.Stdout)
+}
+
+// ParsePorcelainV2Status 解析 git status --porcelain=v2 -z --branch 的输出
+func ParsePorcelainV2Status(output string) (*models.GitStatus, error) {
+	status := &models.GitStatus{Files: []models.GitFileStatus{}}
+	entries := strings.Split(output, "\x00")
+
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if entry == "" {
+			continue
+		}
+
+		switch entry[0] {
+		case '#':
+			parseBranchHeader(&status.Branch, entry)
+		case '1':
+			// 1 XY sub mH mI mW hH hI path
+			fields := strings.SplitN(entry, " ", 9)
+			if len(fields) < 9 {
+				return nil, fmt.Errorf("invalid status entry: %q", entry)
+			}
+			status.Files = append(status.Files, newChangedFile(fields[1], fields[2], fields[8]))
+		case '2':
+			// 2 XY sub mH mI mW hH hI Xscore path <NUL> origPath
+			fields := strings.SplitN(entry, " ", 10)
+			if len(fields) < 10 || i+1 >= len(entries) {
+				return nil, fmt.Errorf("invalid rename entry: %q", entry)
+			}
+			file := newChangedFile(fields[1], fields[2], fields[9])
+			file.Score, _ = strconv.Atoi(fields[8][1:])
+			i++
+			file.OrigFilename = entries[i]
+			status.Files = append(status.Files, file)
+		case 'u':
+			// u XY sub m1 m2 m3 mW h1 h2 h3 path
+			fields := strings.SplitN(entry, " ", 11)
+			if len(fields) < 11 {
+				return nil, fmt.Errorf("invalid unmerged entry: %q", entry)
+			}
+			file := newChangedFile(fields[1], fields[2], fields[10])
+			file.Status = "U"
+			file.Staged = false
+			file.Unmerged = true
+			status.Files = append(status.Files, file)
+		case '?':
+			status.Files = append(status.Files, models.GitFileStatus{
+				Filename:       strings.TrimPrefix(entry, "? "),
+				Status:         "?",
+				IndexStatus:    "?",
+				WorktreeStatus: "?",
+				Untracked:      true,
+			})
+		case '!':
+			// 忽略的文件不展示
+		default:
+			return nil, fmt.Errorf("unknown status entry: %q", entry)
+		}
+	}
+
+	return status, nil
+}
+
+// parseBranchHeader 解析 # branch.* 头信息
+func parseBranchHeader(branch *models.GitBranchStatus, header string) {
+	key, value, _ := strings.Cut(strings.TrimPrefix(header, "# "), " ")
+	switch key {
+	case "branch.oid":
+		if value != "(initial)" {
+			branch.OID = value
+		}
+	case "branch.head":
+		if value == "(detached)" {
+			branch.Detached = true
+		} else {
+			branch.Head = value
+		}
+	case "branch.upstream":
+		branch.Upstream = value
+	case "branch.ab":
+		// branch.ab +<ahead> -<behind>
+		if ahead, behind, ok := strings.Cut(value, " "); ok {
+			branch.Ahead, _ = strconv.Atoi(strings.TrimPrefix(ahead, "+"))
+			branch.Behind, _ = strconv.Atoi(strings.TrimPrefix(behind, "-"))
+		}
+	}
+}
+
+// newChangedFile 根据 XY 状态码与子模块字段构造文件状态
+func newChangedFile(xy, sub, path string) models.GitFileStatus {
+	x, y := string(xy[0]), string(xy[1])
+	file := models.GitFileStatus{
+		Filename:       path,
+		IndexStatus:    x,
+		WorktreeStatus: y,
+		Staged:         x != ".",
+		Status:         y,
+	}
+	if y == "." {
+		file.Status = x
+	}
+
+	// sub 字段: N... 表示普通文件，S<c><m><u> 表示子模块
+	if strings.HasPrefix(sub, "S") && len(sub) == 4 {
+		file.Submodule = &models.GitSubmoduleStatus{
+			CommitChanged:    sub[1] == 'C',
+			TrackedChanges:   sub[2] == 'M',
+			UntrackedChanges: sub[3] == 'U',
+		}
+	}
+
+	return file
+}
@@ -0,0 +1,145 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-git-client-window/models"
+)
+
+func TestParsePorcelainV2Status(t *testing.T) {
+	output := "# branch.oid 1234567890abcdef1234567890abcdef12345678\x00" +
+		"# branch.head main\x00" +
+		"# branch.upstream origin/main\x00" +
+		"# branch.ab +2 -1\x00" +
+		"1 M. N... 100644 100644 100644 aaaa bbbb staged only.txt\x00" +
+		"1 .M N... 100644 100644 100644 aaaa aaaa unstaged.txt\x00" +
+		"1 MM N... 100644 100644 100644 aaaa bbbb both.txt\x00" +
+		"2 R. N... 100644 100644 100644 aaaa aaaa R95 new name.txt\x00old \"name\".txt\x00" +
+		"u UU N... 100644 100644 100644 100644 aaaa bbbb cccc conflict.txt\x00" +
+		"1 .M SC.U 160000 160000 160000 aaaa aaaa lib/sub\x00" +
+		"? untracked file.txt\x00"
+
+	status, err := ParsePorcelainV2Status(output)
+	require.NoError(t, err)
+
+	assert.Equal(t, models.GitBranchStatus{
+		OID:      "1234567890abcdef1234567890abcdef12345678",
+		Head:     "main",
+		Upstream: "origin/main",
+		Ahead:    2,
+		Behind:   1,
+	}, status.Branch)
+
+	require.Len(t, status.Files, 7)
+
+	assert.Equal(t, "staged only.txt", status.Files[0].Filename)
+	assert.True(t, status.Files[0].Staged)
+	assert.Equal(t, "M", status.Files[0].IndexStatus)
+	assert.Equal(t, ".", status.Files[0].WorktreeStatus)
+
+	assert.False(t, status.Files[1].Staged)
+	assert.Equal(t, "M", status.Files[1].Status)
+
+	assert.True(t, status.Files[2].Staged)
+	assert.Equal(t, "M", status.Files[2].WorktreeStatus)
+
+	rename := status.Files[3]
+	assert.Equal(t, "R", rename.Status)
+	assert.Equal(t, "new name.txt", rename.Filename)
+	assert.Equal(t, `old "name".txt`, rename.OrigFilename)
+	assert.Equal(t, 95, rename.Score)
+
+	assert.True(t, status.Files[4].Unmerged)
+	assert.Equal(t, "U", status.Files[4].Status)
+
+	require.NotNil(t, status.Files[5].Submodule)
+	assert.True(t, status.Files[5].Submodule.CommitChanged)
+	assert.False(t, status.Files[5].Submodule.TrackedChanges)
+	assert.True(t, status.Files[5].Submodule.UntrackedChanges)
+
+	assert.True(t, status.Files[6].Untracked)
+	assert.Equal(t, "untracked file.txt", status.Files[6].Filename)
+}
+
+func TestGetRepoStatus(t *testing.T) {
+	dir := newTestRepo(t)
+	writeTestFile(t, dir, "with space.txt", "content\n")
+	require.NoError(t, os.Rename(filepath.Join(dir, "README.md"), filepath.Join(dir, "README.txt")))
+	mustGit(t, dir, "add", "-A")
+	writeTestFile(t, dir, "with space.txt", "changed\n")
+
+	status, err := gitService.GetRepoStatus(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "master", status.Branch.Head)
+	assert.NotEmpty(t, status.Branch.OID)
+
+	files := make(map[string]models.GitFileStatus)
+	for _, file := range status.Files {
+		files[file.Filename] = file
+	}
+	assert.Equal(t, "README.md", files["README.txt"].OrigFilename)
+	assert.Equal(t, "A", files["with space.txt"].IndexStatus)
+	assert.Equal(t, "M", files["with space.txt"].WorktreeStatus)
+}
+
+func TestStagedFilesAndFileActions(t *testing.T) {
+	dir := t.TempDir()
+	mustGit(t, dir, "init", "-b", "master")
+	writeTestFile(t, dir, "new.txt", "new\n")
+	mustGit(t, dir, "add", "new.txt")
+
+	// 尚无提交时也能查询与取消暂存
+	staged, err := gitService.GetStagedFiles(dir)
+	require.NoError(t, err)
+	require.Len(t, staged, 1)
+	assert.Equal(t, "A", staged[0].Status)
+	_, err = gitService.UnstageFile(dir, "new.txt")
+	require.NoError(t, err)
+	staged, err = gitService.GetStagedFiles(dir)
+	require.NoError(t, err)
+	assert.Empty(t, staged)
+
+	dir = newTestRepo(t)
+	writeTestFile(t, dir, "notes.txt", "one\ntwo\nthree\n")
+	mustGit(t, dir, "add", "notes.txt")
+	mustGit(t, dir, "commit", "-m", "notes | pipes")
+	mustGit(t, dir, "mv", "notes.txt", "moved.txt")
+	writeTestFile(t, dir, "README.md", "changed\n")
+	_, err = gitService.StageAll(dir)
+	require.NoError(t, err)
+
+	staged, err = gitService.GetStagedFiles(dir)
+	require.NoError(t, err)
+	require.Len(t, staged, 2)
+	assert.Equal(t, models.GitFileStatus{Filename: "README.md", Status: "M", IndexStatus: "M", Staged: true}, staged[0])
+	assert.Equal(t, "R", staged[1].Status)
+	assert.Equal(t, "notes.txt", staged[1].OrigFilename)
+	assert.Equal(t, "moved.txt", staged[1].Filename)
+	assert.Equal(t, 100, staged[1].Score)
+
+	_, err = gitService.UnstageFile(dir, "README.md")
+	require.NoError(t, err)
+	_, err = gitService.CheckoutFile(dir, "README.md")
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", mustGit(t, dir, "show", ":README.md"))
+	status, err := gitService.GetRepoStatus(dir)
+	require.NoError(t, err)
+	for _, file := range status.Files {
+		assert.NotEqual(t, "README.md", file.Filename)
+	}
+
+	log, err := gitService.GetLog(dir, 5)
+	require.NoError(t, err)
+	require.Len(t, log, 2)
+	assert.Equal(t, strings.TrimSpace(mustGit(t, dir, "rev-parse", "HEAD~1")), log[1].Hash)
+	// 标题中的 | 不影响其他字段
+	assert.Equal(t, "notes | pipes", log[0].Subject)
+	assert.Equal(t, "tester", log[0].AuthorName)
+	assert.Equal(t, []string{"HEAD -> master"}, log[0].Branches)
+}
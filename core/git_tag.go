@@ -0,0 +1,183 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go-git-client-window/models"
+)
+
+// tagFormat for-each-ref 输出格式，字段以 \x1f 分隔，记录以 \x1e 结尾（说明可能跨多行）
+const tagFormat = "%(refname:strip=2)%1f%(objecttype)%1f%(objectname)%1f%(*objectname)%1f" +
+	"%(taggername)%1f%(taggeremail)%1f%(creatordate:iso)%1f%(contents)%1f%(contents:signature)%1e"
+
+// GetTags 获取标签列表，sortBy 可选 version/date/name
+func (s *GitCoreService) GetTags(repoPath, sortBy string) ([]models.GitTag, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+
+	var sortKey string
+	switch sortBy {
+	case models.TagSortVersion, "":
+		sortKey = "-v:refname"
+	case models.TagSortDate:
+		sortKey = "-creatordate"
+	case models.TagSortName:
+		sortKey = "refname"
+	default:
+		return nil, fmt.Errorf("unsupported tag sort: %s", sortBy)
+	}
+
+	result, err := runGit(repoPath, "for-each-ref", "refs/tags", "--sort="+sortKey, "--format="+tagFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseTagRecords(result.Stdout)
+}
+
+// ParseTagRecords 解析 for-each-ref 输出的标签记录
+func ParseTagRecords(output string) ([]models.GitTag, error) {
+	tags := []models.GitTag{}
+	for _, record := range strings.Split(output, "\x1e") {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+
+		parts := strings.Split(record, "\x1f")
+		if len(parts) < 9 {
+			return nil, fmt.Errorf("invalid tag record: %q", record)
+		}
+
+		tag := models.GitTag{
+			Name:   parts[0],
+			Object: parts[2],
+			Target: parts[2],
+			Date:   parts[6],
+		}
+		if parts[1] == "tag" {
+			tag.Annotated = true
+			tag.Target = parts[3]
+			tag.Tagger = parts[4]
+			tag.TaggerEmail = strings.Trim(parts[5], "<>")
+			signature := parts[8]
+			tag.HasSignature = signature != ""
+			tag.Message = strings.TrimSpace(strings.TrimSuffix(parts[7], signature))
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// CreateTag 创建标签，设置 Message 或 Sign 时创建附注标签
+func (s *GitCoreService) CreateTag(repoPath string, opts models.GitTagCreateOptions) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+	if strings.TrimSpace(opts.Name) == "" {
+		return "", fmt.Errorf("tag name cannot be empty")
+	}
+	// 签名标签没有 --message 时 git 会打开编辑器等待输入，界面中会一直挂起
+	if opts.Sign && strings.TrimSpace(opts.Message) == "" {
+		return "", fmt.Errorf("signed tag requires a message")
+	}
+	if err := checkRevisionArgs([]string{opts.Name, opts.Target}); err != nil {
+		return "", err
+	}
+
+	args := []string{"tag"}
+	if opts.Sign {
+		args = append(args, "--sign")
+	} else if opts.Message != "" {
+		args = append(args, "--annotate")
+	}
+	if opts.Message != "" {
+		args = append(args, "--message", opts.Message)
+	}
+	if opts.Force {
+		args = append(args, "--force")
+	}
+	args = append(args, opts.Name)
+	if opts.Target != "" {
+		args = append(args, opts.Target)
+	}
+
+	output, err := ExecuteGitCommand(repoPath, args...)
+	if err != nil {
+		return "", err
+	}
+
+	return output, nil
+}
+
+// DeleteTag 删除本地标签
+func (s *GitCoreService) DeleteTag(repoPath, name string) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+
+	if err := checkRevisionArgs([]string{name}); err != nil {
+		return "", err
+	}
+
+	output, err := ExecuteGitCommand(repoPath, "tag", "--delete", name)
+	if err != nil {
+		return "", err
+	}
+
+	return output, nil
+}
+
+// DeleteRemoteTag 删除远程标签
+func (s *GitCoreService) DeleteRemoteTag(ctx context.Context, repoPath, remote, name string) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+
+	output, err := ExecuteGitCommandContext(ctx, repoPath, "push", defaultRemote(remote), "--delete", "refs/tags/"+name)
+	if err != nil {
+		return "", err
+	}
+
+	return output, nil
+}
+
+// PushTag 推送单个标签
+func (s *GitCoreService) PushTag(ctx context.Context, repoPath, remote, name string, onProgress ProgressFunc) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+
+	result, err := runWithProgress(ctx, repoPath, onProgress, "push", "--progress", defaultRemote(remote), "refs/tags/"+name)
+	if err != nil {
+		return "", err
+	}
+
+	return progressOutput(result), nil
+}
+
+// PushAllTags 推送全部标签
+func (s *GitCoreService) PushAllTags(ctx context.Context, repoPath, remote string, onProgress ProgressFunc) (string, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+
+	result, err := runWithProgress(ctx, repoPath, onProgress, "push", "--progress", defaultRemote(remote), "--tags")
+	if err != nil {
+		return "", err
+	}
+
+	return progressOutput(result), nil
+}
+
+// defaultRemote 未指定远程仓库时使用 origin
+func defaultRemote(remote string) string {
+	if strings.TrimSpace(remote) == "" {
+		return "origin"
+	}
+	return remote
+}
@@ -0,0 +1,77 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-git-client-window/models"
+)
+
+func TestParseRefNames(t *testing.T) {
+	branches, tags := ParseRefNames("HEAD -> main, tag: v1.0.0, origin/main, tag: latest")
+	assert.Equal(t, []string{"HEAD -> main", "origin/main"}, branches)
+	assert.Equal(t, []string{"v1.0.0", "latest"}, tags)
+}
+
+func TestTagLifecycle(t *testing.T) {
+	dir := newTestRepo(t)
+	first := mustGit(t, dir, "rev-parse", "HEAD")
+	writeTestFile(t, dir, "README.md", "second\n")
+	mustGit(t, dir, "commit", "-am", "second")
+
+	_, err := gitService.CreateTag(dir, models.GitTagCreateOptions{Name: "v1.2.0", Target: "HEAD~1"})
+	require.NoError(t, err)
+	_, err = gitService.CreateTag(dir, models.GitTagCreateOptions{Name: "v1.10.0", Message: "release 1.10\n\nnotes"})
+	require.NoError(t, err)
+	_, err = gitService.CreateTag(dir, models.GitTagCreateOptions{Name: "v1.10.0"})
+	assert.True(t, IsGitErrorKind(err, ErrKindAlreadyExists))
+	_, err = gitService.CreateTag(dir, models.GitTagCreateOptions{Name: "v2.0.0", Sign: true})
+	assert.ErrorContains(t, err, "requires a message")
+	_, err = gitService.CreateTag(dir, models.GitTagCreateOptions{Name: "--list"})
+	assert.Error(t, err)
+	_, err = gitService.CreateTag(dir, models.GitTagCreateOptions{Name: "v2.0.0", Target: "--contains"})
+	assert.Error(t, err)
+
+	tags, err := gitService.GetTags(dir, models.TagSortVersion)
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+
+	annotated := tags[0]
+	assert.Equal(t, "v1.10.0", annotated.Name)
+	assert.True(t, annotated.Annotated)
+	assert.NotEqual(t, annotated.Object, annotated.Target)
+	assert.Equal(t, "tester", annotated.Tagger)
+	assert.Equal(t, "tester@example.com", annotated.TaggerEmail)
+	assert.Equal(t, "release 1.10\n\nnotes", annotated.Message)
+	assert.False(t, annotated.HasSignature)
+
+	lightweight := tags[1]
+	assert.Equal(t, "v1.2.0", lightweight.Name)
+	assert.False(t, lightweight.Annotated)
+	assert.Equal(t, first[:40], lightweight.Target)
+
+	t.Run("推送与删除远程标签", func(t *testing.T) {
+		remote := t.TempDir()
+		mustGit(t, remote, "init", "--bare")
+		mustGit(t, dir, "remote", "add", "origin", remote)
+
+		_, err := gitService.PushTag(context.Background(), dir, "", "v1.2.0", nil)
+		require.NoError(t, err)
+		_, err = gitService.PushAllTags(context.Background(), dir, "origin", nil)
+		require.NoError(t, err)
+		assert.Contains(t, mustGit(t, remote, "tag"), "v1.10.0")
+
+		_, err = gitService.DeleteRemoteTag(context.Background(), dir, "origin", "v1.2.0")
+		require.NoError(t, err)
+		assert.NotContains(t, mustGit(t, remote, "tag"), "v1.2.0")
+	})
+
+	_, err = gitService.DeleteTag(dir, "v1.2.0")
+	require.NoError(t, err)
+	tags, err = gitService.GetTags(dir, models.TagSortName)
+	require.NoError(t, err)
+	assert.Len(t, tags, 1)
+}
@@ -3,13 +3,19 @@ package main
 import (
 	"context"
 	"embed"
+	"errors"
+	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/menu"
 	"github.com/wailsapp/wails/v2/pkg/options"
 	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 
 	"go-git-client-window/core"
 	"go-git-client-window/models"
@@ -18,6 +24,16 @@ import (
 
 var log = slog.New(slog.NewTextHandler(os.Stdout, nil))
 
+// 推送给前端的事件名称
+const (
+	eventGitProgress = "git:progress" // 远程操作进度，数据为 models.GitProgress
+	eventGitComplete = "git:complete" // 长时间操作结束，数据为 models.GitOperationResult
+	eventGitBlame    = "git:blame"    // 增量 blame 结果，数据为 models.GitBlameEvent
+
+	eventGitCredentialRequest = "git:credential-request" // git 需要凭据，数据为 models.GitCredentialRequest，通过 GitCredentialRespond 回复
+	eventGitCredentialDone    = "git:credential-done"    // 凭据请求结束（已回复、取消或超时），数据为请求 ID
+)
+
 //go:embed all:frontend/dist
 var assets embed.FS
 
@@ -25,13 +41,19 @@ var assets embed.FS
 type App struct {
 	ctx        context.Context
 	gitService *core.GitCoreService
+
+	credentialBridge   *core.CredentialBridge
+	credentialStore    *core.CredentialStore
+	credentialMu       sync.Mutex
+	credentialRequests map[string]chan models.GitCredentialResponse
 }
 
 // NewApp creates a new App application struct
 func NewApp() *App {
 	gitCoreService := core.NewGitCoreService()
 	return &App{
-		gitService: gitCoreService,
+		gitService:         gitCoreService,
+		credentialRequests: map[string]chan models.GitCredentialResponse{},
 	}
 }
 
@@ -45,6 +67,17 @@ func (a *App) OpenInBrowser(url string) error {
 	return utils.OpenInBrowser(url)
 }
 
+// SelectDirectory 打开系统目录选择对话框，用户取消时返回空字符串
+func (a *App) SelectDirectory() (string, error) {
+	if a.ctx == nil {
+		return "", fmt.Errorf("application is not started")
+	}
+	return runtime.OpenDirectoryDialog(a.ctx, runtime.OpenDialogOptions{
+		Title:                "选择 Git 仓库",
+		CanCreateDirectories: true,
+	})
+}
+
 // GitInit 初始化Git仓库
 func (a *App) GitInit(path string) (string, error) {
 	return a.gitService.Init(path)
@@ -55,9 +88,21 @@ func (a *App) GitStatus(path string) (string, error) {
 	return a.gitService.Status(path)
 }
 
-// GitClone 克隆Git仓库
-func (a *App) GitClone(repoURL, targetPath string) (string, error) {
-	return a.gitService.Clone(repoURL, targetPath)
+// GitClone 克隆Git仓库，进度通过 git:progress 事件推送，operationID 可用于 GitCancelOperation 取消
+func (a *App) GitClone(operationID, repoURL, targetPath string) (string, error) {
+	return a.runNetworkOperation(operationID, func(ctx context.Context, onProgress core.ProgressFunc) (string, error) {
+		return a.gitService.Clone(ctx, repoURL, targetPath, onProgress)
+	})
+}
+
+// GitCancelOperation 取消正在执行的 Git 操作
+func (a *App) GitCancelOperation(operationID string) error {
+	return a.gitService.CancelOperation(operationID)
+}
+
+// GitRunningOperations 获取正在执行的 Git 操作
+func (a *App) GitRunningOperations() []string {
+	return a.gitService.RunningOperations()
 }
 
 // GitAdd 添加文件到暂存区
@@ -65,6 +110,31 @@ func (a *App) GitAdd(path, files string) (string, error) {
 	return a.gitService.Add(path, files)
 }
 
+// GitAddAll 暂存所有变更
+func (a *App) GitAddAll(path string) (string, error) {
+	return a.gitService.StageAll(path)
+}
+
+// GitReset 取消暂存文件
+func (a *App) GitReset(path, file string) (string, error) {
+	return a.gitService.UnstageFile(path, file)
+}
+
+// GitCheckoutFile 丢弃文件在工作区的改动
+func (a *App) GitCheckoutFile(path, file string) (string, error) {
+	return a.gitService.CheckoutFile(path, file)
+}
+
+// GitLog 获取当前分支最近 limit 条提交日志
+func (a *App) GitLog(path string, limit int) ([]models.GitLogEntry, error) {
+	return a.gitService.GetLog(path, limit)
+}
+
+// GitStagedFiles 获取已暂存文件
+func (a *App) GitStagedFiles(path string) ([]models.GitFileStatus, error) {
+	return a.gitService.GetStagedFiles(path)
+}
+
 // GitCommit 提交更改
 func (a *App) GitCommit(path, message string) (string, error) {
 	return a.gitService.Commit(path, message)
@@ -88,6 +158,67 @@ func (a *App) GitRemoteBranches(path string) (string, error) {
 	return utils.ToJsonString(result), nil
 }
 
+// GitSetUpstream 设置分支的上游
+func (a *App) GitSetUpstream(path, branch, upstream string) (string, error) {
+	return a.gitService.SetUpstream(path, branch, upstream)
+}
+
+// GitUnsetUpstream 取消分支的上游
+func (a *App) GitUnsetUpstream(path, branch string) (string, error) {
+	return a.gitService.UnsetUpstream(path, branch)
+}
+
+// GitPushNewBranch 推送新分支并设置上游
+func (a *App) GitPushNewBranch(operationID, path, remote, branch string) (string, error) {
+	return a.runNetworkOperation(operationID, func(ctx context.Context, onProgress core.ProgressFunc) (string, error) {
+		return a.gitService.PushNewBranch(ctx, path, remote, branch, onProgress)
+	})
+}
+
+// GitDeleteBranch 删除已合并的本地分支
+func (a *App) GitDeleteBranch(path, branch string) (string, error) {
+	return a.gitService.DeleteBranch(path, branch, false)
+}
+
+// GitForceDeleteBranch 强制删除本地分支（-D）
+func (a *App) GitForceDeleteBranch(path, branch string) (string, error) {
+	return a.gitService.DeleteBranch(path, branch, true)
+}
+
+// GitUnmergedCommits 获取分支中未合并到 target 的提交
+func (a *App) GitUnmergedCommits(path, branch, target string) ([]models.GitLogEntry, error) {
+	return a.gitService.GetUnmergedCommits(path, branch, target)
+}
+
+// GitRenameBranch 重命名分支，可同时重命名远程上游分支
+func (a *App) GitRenameBranch(operationID, path, oldName, newName string, renameUpstream bool) (string, error) {
+	return a.runNetworkOperation(operationID, func(ctx context.Context, onProgress core.ProgressFunc) (string, error) {
+		return a.gitService.RenameBranch(ctx, path, oldName, newName, renameUpstream, onProgress)
+	})
+}
+
+// GitDeleteRemoteBranch 删除远程分支
+func (a *App) GitDeleteRemoteBranch(operationID, path, remote, branch string) (string, error) {
+	return a.runOperation(operationID, core.DefaultNetworkTimeout, func(ctx context.Context) (string, error) {
+		return a.gitService.DeleteRemoteBranch(ctx, path, remote, branch)
+	})
+}
+
+// GitResetBranch 将分支重置到指定提交
+func (a *App) GitResetBranch(path, branch, commit, mode string) (string, error) {
+	return a.gitService.ResetBranch(path, branch, commit, mode)
+}
+
+// GitMergedBranches 获取已合并到 target 的本地分支
+func (a *App) GitMergedBranches(path, target string) ([]models.GitBranch, error) {
+	return a.gitService.GetMergedBranches(path, target)
+}
+
+// GitDeleteMergedBranches 批量删除已合并到 target 的本地分支
+func (a *App) GitDeleteMergedBranches(path, target string, exclude []string) ([]models.GitBranchDeleteResult, error) {
+	return a.gitService.DeleteMergedBranches(path, target, exclude)
+}
+
 // GitBranchLog 获取特定分支的提交日志（oneline 格式）
 func (a *App) GitBranchLog(path, branch string, limit int) (string, error) {
 	result, err := a.gitService.GetBranchLog(path, branch, limit)
@@ -148,6 +279,11 @@ func (a *App) GitGetStatusStructured(path string) ([]models.GitFileStatus, error
 	return a.gitService.GetStatusStructured(path)
 }
 
+// GitGetRepoStatus 获取仓库状态（含分支、上游及领先/落后信息）
+func (a *App) GitGetRepoStatus(path string) (*models.GitStatus, error) {
+	return a.gitService.GetRepoStatus(path)
+}
+
 // GitGetStagedFiles 获取已暂存文件列表
 func (a *App) GitGetStagedFiles(path string) ([]models.GitFileStatus, error) {
 	return a.gitService.GetStagedFiles(path)
@@ -163,6 +299,21 @@ func (a *App) GitUnstageFile(path, filename string) (string, error) {
 	return a.gitService.UnstageFile(path, filename)
 }
 
+// GitStageSelection 暂存文件中选中的差异块或行
+func (a *App) GitStageSelection(path string, selection models.DiffSelection) (string, error) {
+	return a.gitService.StageSelection(path, selection)
+}
+
+// GitUnstageSelection 取消暂存文件中选中的差异块或行
+func (a *App) GitUnstageSelection(path string, selection models.DiffSelection) (string, error) {
+	return a.gitService.UnstageSelection(path, selection)
+}
+
+// GitDiscardSelection 丢弃工作区中选中的差异块或行
+func (a *App) GitDiscardSelection(path string, selection models.DiffSelection) (string, error) {
+	return a.gitService.DiscardSelection(path, selection)
+}
+
 // GitStageAll 暂存所有变更
 func (a *App) GitStageAll(path string) (string, error) {
 	return a.gitService.StageAll(path)
@@ -174,18 +325,68 @@ func (a *App) GitResetFile(path, filename string) (string, error) {
 }
 
 // GitFetch 获取远程更新
-func (a *App) GitFetch(path string) (string, error) {
-	return a.gitService.Fetch(path)
+func (a *App) GitFetch(operationID, path string) (string, error) {
+	return a.runNetworkOperation(operationID, func(ctx context.Context, onProgress core.ProgressFunc) (string, error) {
+		return a.gitService.Fetch(ctx, path, onProgress)
+	})
 }
 
 // GitPull 拉取分支
-func (a *App) GitPull(path, branch string) (string, error) {
-	return a.gitService.Pull(path, branch)
+func (a *App) GitPull(operationID, path, branch string) (string, error) {
+	return a.runNetworkOperation(operationID, func(ctx context.Context, onProgress core.ProgressFunc) (string, error) {
+		return a.gitService.Pull(ctx, path, branch, onProgress)
+	})
 }
 
 // GitPush 推送分支
-func (a *App) GitPush(path, branch string, force bool) (string, error) {
-	return a.gitService.Push(path, branch, force)
+func (a *App) GitPush(operationID, path, branch string) (string, error) {
+	return a.runNetworkOperation(operationID, func(ctx context.Context, onProgress core.ProgressFunc) (string, error) {
+		return a.gitService.Push(ctx, path, branch, onProgress)
+	})
+}
+
+// GitFetchWithOptions 按指定远程仓库与 refspec 获取
+func (a *App) GitFetchWithOptions(operationID, path string, opts models.GitFetchOptions) (string, error) {
+	return a.runNetworkOperation(operationID, func(ctx context.Context, onProgress core.ProgressFunc) (string, error) {
+		return a.gitService.FetchWithOptions(ctx, path, opts, onProgress)
+	})
+}
+
+// GitPullWithOptions 按指定整合方式拉取，返回结构化结果
+func (a *App) GitPullWithOptions(operationID, path string, opts models.GitPullOptions) (*models.GitPullResult, error) {
+	var pull *models.GitPullResult
+	_, err := a.runNetworkOperation(operationID, func(ctx context.Context, onProgress core.ProgressFunc) (string, error) {
+		var err error
+		if pull, err = a.gitService.PullWithOptions(ctx, path, opts, onProgress); err != nil {
+			return "", err
+		}
+		return pull.Output, nil
+	})
+	return pull, err
+}
+
+// GitPushWithOptions 推送到指定远程仓库
+func (a *App) GitPushWithOptions(operationID, path string, opts models.GitPushOptions) (string, error) {
+	return a.runNetworkOperation(operationID, func(ctx context.Context, onProgress core.ProgressFunc) (string, error) {
+		return a.gitService.PushWithOptions(ctx, path, opts, onProgress)
+	})
+}
+
+// GitPreviewPush 预览推送，列出强制推送会覆盖的远程提交
+func (a *App) GitPreviewPush(operationID, path string, opts models.GitPushOptions) (*models.GitPushPreview, error) {
+	ctx, done := a.gitService.BeginOperation(a.ctx, operationID, core.DefaultNetworkTimeout)
+	defer done()
+	return a.gitService.PreviewPush(ctx, path, opts)
+}
+
+// GitProtectedBranches 获取仓库的受保护分支规则
+func (a *App) GitProtectedBranches(path string) ([]string, error) {
+	return a.gitService.GetProtectedBranches(path)
+}
+
+// GitSetProtectedBranches 设置仓库的受保护分支规则
+func (a *App) GitSetProtectedBranches(path string, patterns []string) error {
+	return a.gitService.SetProtectedBranches(path, patterns)
 }
 
 // GitGetRemoteInfo 获取远程仓库信息
@@ -193,16 +394,123 @@ func (a *App) GitGetRemoteInfo(path string) ([]models.GitRemoteInfo, error) {
 	return a.gitService.GetRemoteInfo(path)
 }
 
+// GitAddRemote 添加远程仓库
+func (a *App) GitAddRemote(path, name, url string) (string, error) {
+	return a.gitService.AddRemote(path, name, url)
+}
+
+// GitRemoveRemote 删除远程仓库
+func (a *App) GitRemoveRemote(path, name string) (string, error) {
+	return a.gitService.RemoveRemote(path, name)
+}
+
+// GitRenameRemote 重命名远程仓库
+func (a *App) GitRenameRemote(path, oldName, newName string) (string, error) {
+	return a.gitService.RenameRemote(path, oldName, newName)
+}
+
+// GitSetRemoteURL 修改远程仓库的获取或推送地址
+func (a *App) GitSetRemoteURL(path, name, url string, push bool) (string, error) {
+	return a.gitService.SetRemoteURL(path, name, url, push)
+}
+
+// GitPruneRemote 清理远程已删除的跟踪分支
+func (a *App) GitPruneRemote(operationID, path, name string) (string, error) {
+	return a.runOperation(operationID, core.DefaultNetworkTimeout, func(ctx context.Context) (string, error) {
+		return a.gitService.PruneRemote(ctx, path, name)
+	})
+}
+
 // GitMerge 合并分支
 func (a *App) GitMerge(path, branch string) (string, error) {
 	return a.gitService.Merge(path, branch)
 }
 
+// GitMergeWithOptions 按选项合并分支（--no-ff/--ff-only/--squash/-s/-X 等）
+func (a *App) GitMergeWithOptions(path string, opts models.GitMergeOptions) (string, error) {
+	return a.gitService.MergeWithOptions(path, opts)
+}
+
+// GitMergePreview 预演合并，判断能否快进或是否会冲突
+func (a *App) GitMergePreview(path, branch string) (*models.GitMergePreview, error) {
+	return a.gitService.PreviewMerge(path, branch)
+}
+
+// GitMergeAbort 放弃合并
+func (a *App) GitMergeAbort(path string) (string, error) {
+	return a.gitService.MergeAbort(path)
+}
+
+// GitMergeContinue 完成合并
+func (a *App) GitMergeContinue(path string) (string, error) {
+	return a.gitService.MergeContinue(path)
+}
+
 // GitRebase 变基操作
 func (a *App) GitRebase(path, branch string) (string, error) {
 	return a.gitService.Rebase(path, branch)
 }
 
+// GitRepoState 获取仓库进行中的操作（merge/rebase/cherry-pick/revert/bisect）及可执行的动作
+func (a *App) GitRepoState(path string) (*models.GitRepoState, error) {
+	return a.gitService.GetRepoState(path)
+}
+
+// GitCherryPick 将提交应用到当前分支
+func (a *App) GitCherryPick(path string, opts models.GitCherryPickOptions) (string, error) {
+	return a.gitService.CherryPick(path, opts)
+}
+
+// GitRevert 撤销提交
+func (a *App) GitRevert(path string, opts models.GitCherryPickOptions) (string, error) {
+	return a.gitService.Revert(path, opts)
+}
+
+// GitSequencerState 获取进行中的 cherry-pick / revert 状态
+func (a *App) GitSequencerState(path string) (*models.GitSequencerState, error) {
+	return a.gitService.GetSequencerState(path)
+}
+
+// GitSequencerContinue 继续 cherry-pick / revert
+func (a *App) GitSequencerContinue(path string) (string, error) {
+	return a.gitService.SequencerContinue(path)
+}
+
+// GitSequencerSkip 跳过当前提交
+func (a *App) GitSequencerSkip(path string) (string, error) {
+	return a.gitService.SequencerSkip(path)
+}
+
+// GitSequencerAbort 放弃 cherry-pick / revert
+func (a *App) GitSequencerAbort(path string) (string, error) {
+	return a.gitService.SequencerAbort(path)
+}
+
+// GitRebasePlan 获取交互式变基的默认计划
+func (a *App) GitRebasePlan(path, base string) ([]models.GitRebaseTodoItem, error) {
+	return a.gitService.GetRebasePlan(path, base)
+}
+
+// GitRebaseInteractive 按计划执行交互式变基
+func (a *App) GitRebaseInteractive(path string, plan models.GitRebasePlan) (string, error) {
+	return a.gitService.RebaseInteractive(path, plan)
+}
+
+// GitRebaseContinue 继续变基
+func (a *App) GitRebaseContinue(path string) (string, error) {
+	return a.gitService.RebaseContinue(path)
+}
+
+// GitRebaseSkip 跳过当前提交
+func (a *App) GitRebaseSkip(path string) (string, error) {
+	return a.gitService.RebaseSkip(path)
+}
+
+// GitRebaseAbort 放弃变基
+func (a *App) GitRebaseAbort(path string) (string, error) {
+	return a.gitService.RebaseAbort(path)
+}
+
 // GitGetMergeConflicts 获取合并冲突列表
 func (a *App) GitGetMergeConflicts(path string) ([]string, error) {
 	return a.gitService.GetMergeConflicts(path)
@@ -213,6 +521,16 @@ func (a *App) GitResolveConflict(path, filename, strategy string) (string, error
 	return a.gitService.ResolveConflict(path, filename, strategy)
 }
 
+// GitConflictFile 获取冲突文件的三方内容与冲突区域
+func (a *App) GitConflictFile(path, filename string) (*models.GitConflictFile, error) {
+	return a.gitService.GetConflictFile(path, filename)
+}
+
+// GitResolveConflictContent 按区域选择或编辑后的内容解决冲突
+func (a *App) GitResolveConflictContent(path string, resolution models.GitConflictResolution) (string, error) {
+	return a.gitService.ResolveConflictContent(path, resolution)
+}
+
 // GitStashList 获取 stash 列表
 func (a *App) GitStashList(path string) ([]models.GitStash, error) {
 	return a.gitService.GetStashList(path)
@@ -223,6 +541,21 @@ func (a *App) GitStashSave(path, message string) (string, error) {
 	return a.gitService.StashSave(path, message)
 }
 
+// GitStashPush 保存 stash（支持路径、保留暂存区、包含未跟踪文件等选项）
+func (a *App) GitStashPush(path string, opts models.GitStashPushOptions) (string, error) {
+	return a.gitService.StashPush(path, opts)
+}
+
+// GitStashShow 查看 stash 的结构化差异
+func (a *App) GitStashShow(path, stashId string, includeUntracked bool) ([]models.FileDiff, error) {
+	return a.gitService.StashShow(path, stashId, includeUntracked)
+}
+
+// GitStashBranch 基于 stash 创建分支
+func (a *App) GitStashBranch(path, branch, stashId string) (string, error) {
+	return a.gitService.StashBranch(path, branch, stashId)
+}
+
 // GitStashApply 应用 stash
 func (a *App) GitStashApply(path, stashId string) (string, error) {
 	return a.gitService.StashApply(path, stashId)
@@ -243,6 +576,51 @@ func (a *App) GitBlame(path, filename string) ([]models.GitBlameLine, error) {
 	return a.gitService.GetBlame(path, filename)
 }
 
+// GitBlameFile 获取文件的结构化 blame 结果
+func (a *App) GitBlameFile(path, filename string, opts models.GitBlameOptions) (*models.GitBlame, error) {
+	return a.gitService.BlameFile(path, filename, opts)
+}
+
+// GitBlameIncremental 增量 blame，每组结果通过 git:blame 事件推送，结束时推送 git:complete 事件
+func (a *App) GitBlameIncremental(operationID, path, filename string, opts models.GitBlameOptions) error {
+	_, err := a.runOperation(operationID, 0, func(ctx context.Context) (string, error) {
+		return "", a.gitService.BlameIncremental(ctx, path, filename, opts, func(group models.GitBlameGroup, commit *models.GitBlameCommit) {
+			a.emitEvent(eventGitBlame, models.GitBlameEvent{OperationID: operationID, Group: group, Commit: commit})
+		})
+	})
+	return err
+}
+
+// GitQueryLog 按条件分页查询提交日志
+func (a *App) GitQueryLog(path string, query models.GitLogQuery) (*models.GitLogPage, error) {
+	return a.gitService.QueryLog(path, query)
+}
+
+// GitCommitDetail 获取提交详情与改动文件列表，parent 为空时与第一父提交对比
+func (a *App) GitCommitDetail(path, commit, parent string) (*models.GitCommitDetail, error) {
+	return a.gitService.GetCommitDetail(path, commit, parent)
+}
+
+// GitCommitFileDiff 获取提交中单个文件的结构化差异
+func (a *App) GitCommitFileDiff(path, commit, parent, filename, oldPath string) ([]models.FileDiff, error) {
+	return a.gitService.GetCommitFileDiff(path, commit, parent, filename, oldPath)
+}
+
+// GitCompare 比较两个引用
+func (a *App) GitCompare(path, base, head string) (*models.GitCompareResult, error) {
+	return a.gitService.Compare(path, base, head)
+}
+
+// GitCompareDiff 获取两个引用之间的结构化差异，threeDot 为 true 时使用三点语义
+func (a *App) GitCompareDiff(path, base, head, filename string, threeDot bool) ([]models.FileDiff, error) {
+	return a.gitService.CompareDiff(path, base, head, filename, threeDot)
+}
+
+// GitCommitGraph 获取带列布局与连线的提交图（分页）
+func (a *App) GitCommitGraph(path string, opts models.GitGraphOptions) (*models.GitGraphPage, error) {
+	return a.gitService.GetCommitGraph(path, opts)
+}
+
 // GitGetGraphHistory 获取图形化历史数据
 func (a *App) GitGetGraphHistory(path string, limit int) (string, error) {
 	result, err := a.gitService.GetGraphHistoryWithFormat(path, limit)
@@ -252,6 +630,42 @@ func (a *App) GitGetGraphHistory(path string, limit int) (string, error) {
 	return result, nil
 }
 
+// GitTags 获取标签列表，sortBy 可选 version/date/name
+func (a *App) GitTags(path, sortBy string) ([]models.GitTag, error) {
+	return a.gitService.GetTags(path, sortBy)
+}
+
+// GitCreateTag 创建标签
+func (a *App) GitCreateTag(path string, opts models.GitTagCreateOptions) (string, error) {
+	return a.gitService.CreateTag(path, opts)
+}
+
+// GitDeleteTag 删除本地标签
+func (a *App) GitDeleteTag(path, name string) (string, error) {
+	return a.gitService.DeleteTag(path, name)
+}
+
+// GitDeleteRemoteTag 删除远程标签
+func (a *App) GitDeleteRemoteTag(operationID, path, remote, name string) (string, error) {
+	return a.runOperation(operationID, core.DefaultNetworkTimeout, func(ctx context.Context) (string, error) {
+		return a.gitService.DeleteRemoteTag(ctx, path, remote, name)
+	})
+}
+
+// GitPushTag 推送单个标签
+func (a *App) GitPushTag(operationID, path, remote, name string) (string, error) {
+	return a.runNetworkOperation(operationID, func(ctx context.Context, onProgress core.ProgressFunc) (string, error) {
+		return a.gitService.PushTag(ctx, path, remote, name, onProgress)
+	})
+}
+
+// GitPushAllTags 推送全部标签
+func (a *App) GitPushAllTags(operationID, path, remote string) (string, error) {
+	return a.runNetworkOperation(operationID, func(ctx context.Context, onProgress core.ProgressFunc) (string, error) {
+		return a.gitService.PushAllTags(ctx, path, remote, onProgress)
+	})
+}
+
 // GitCommitAmend 修改最后一次提交
 func (a *App) GitCommitAmend(path, message string) (string, error) {
 	return a.gitService.AmendCommit(path, message)
@@ -264,6 +678,11 @@ func (a *App) GitBranch(path string) (string, error) {
 	return utils.ToJsonString(result), nil
 }
 func main() {
+	// 由 git 作为 credential helper 或 askpass 启动时只转发请求，不启动界面
+	if core.IsCredentialClient() {
+		os.Exit(core.RunCredentialClient(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+	}
+
 	app := NewApp()
 
 	err := wails.Run(&options.App{
@@ -297,7 +716,7 @@ func main() {
 		},
 		EnumBind:                         nil,
 		WindowStartState:                 0,
-		ErrorFormatter:                   nil,
+		ErrorFormatter:                   formatError,
 		CSSDragProperty:                  "",
 		CSSDragValue:                     "",
 		EnableDefaultContextMenu:         false,
@@ -315,6 +734,132 @@ func main() {
 // so we can call the context's lifecycle event methods.
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
+	a.startCredentialBridge()
+}
+
+// startCredentialBridge 启动凭据桥接，git 需要凭据时通过 git:credential-request 事件请求界面输入
+// 启动失败时 git 仍使用默认的凭据处理方式
+func (a *App) startCredentialBridge() {
+	executable, err := os.Executable()
+	if err != nil {
+		log.Error("获取程序路径失败，凭据桥接未启动", "error", err)
+		return
+	}
+
+	if configDir, err := os.UserConfigDir(); err != nil {
+		log.Warn("获取配置目录失败，不保存凭据", "error", err)
+	} else if a.credentialStore, err = core.NewCredentialStore(filepath.Join(configDir, "go-git-client-window"), nil); err != nil {
+		log.Warn("打开凭据存储失败，不保存凭据", "error", err)
+	}
+
+	a.credentialBridge, err = core.NewCredentialBridge(executable, a.promptCredential, a.credentialStore)
+	if err != nil {
+		log.Error("凭据桥接启动失败", "error", err)
+		return
+	}
+	core.SetCredentialBridge(a.credentialBridge)
+}
+
+// promptCredential 推送凭据请求并等待前端通过 GitCredentialRespond 回复
+func (a *App) promptCredential(ctx context.Context, req models.GitCredentialRequest) (*models.GitCredentialResponse, error) {
+	if a.ctx == nil {
+		return nil, fmt.Errorf("application is not started")
+	}
+
+	reply := make(chan models.GitCredentialResponse, 1)
+	a.credentialMu.Lock()
+	a.credentialRequests[req.ID] = reply
+	a.credentialMu.Unlock()
+	defer func() {
+		a.credentialMu.Lock()
+		delete(a.credentialRequests, req.ID)
+		a.credentialMu.Unlock()
+		a.emitEvent(eventGitCredentialDone, req.ID)
+	}()
+
+	a.emitEvent(eventGitCredentialRequest, req)
+	select {
+	case resp := <-reply:
+		return &resp, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// GitCredentialRespond 回复 git:credential-request 事件，用户取消时传 canceled 为 true
+func (a *App) GitCredentialRespond(id string, resp models.GitCredentialResponse) error {
+	a.credentialMu.Lock()
+	reply, ok := a.credentialRequests[id]
+	a.credentialMu.Unlock()
+	if !ok {
+		return fmt.Errorf("credential request %s not found", id)
+	}
+
+	select {
+	case reply <- resp:
+		return nil
+	default:
+		return fmt.Errorf("credential request %s already answered", id)
+	}
+}
+
+// GitClearStoredCredentials 删除全部已保存的凭据
+func (a *App) GitClearStoredCredentials() error {
+	if a.credentialStore == nil {
+		return fmt.Errorf("credential store is not available")
+	}
+	return a.credentialStore.Clear()
+}
+
+// runNetworkOperation 执行可取消的网络操作，过程中推送 git:progress 事件
+func (a *App) runNetworkOperation(operationID string, run func(ctx context.Context, onProgress core.ProgressFunc) (string, error)) (string, error) {
+	return a.runOperation(operationID, core.DefaultNetworkTimeout, func(ctx context.Context) (string, error) {
+		return run(ctx, func(progress models.GitProgress) {
+			progress.OperationID = operationID
+			a.emitEvent(eventGitProgress, progress)
+		})
+	})
+}
+
+// runOperation 执行可取消的长时间操作，结束时推送 git:complete 事件
+func (a *App) runOperation(operationID string, timeout time.Duration, run func(ctx context.Context) (string, error)) (string, error) {
+	ctx, done := a.gitService.BeginOperation(a.ctx, operationID, timeout)
+	defer done()
+
+	output, err := run(ctx)
+	a.emitEvent(eventGitComplete, operationResult(ctx, operationID, output, err))
+
+	return output, err
+}
+
+// operationResult 生成操作结束事件的数据，区分用户取消与超时
+func operationResult(ctx context.Context, operationID, output string, err error) models.GitOperationResult {
+	result := models.GitOperationResult{
+		OperationID: operationID,
+		Success:     err == nil,
+		Canceled:    errors.Is(ctx.Err(), context.Canceled),
+		TimedOut:    errors.Is(ctx.Err(), context.DeadlineExceeded),
+		Output:      output,
+	}
+	if err != nil {
+		gitErr := core.AsGitError(err)
+		result.Error = gitErr.Error()
+		result.ErrorKind = string(gitErr.Kind)
+	}
+	return result
+}
+
+// formatError 将绑定方法返回的错误转换为结构化的 core.GitError，前端可根据 kind 字段区分错误类型
+func formatError(err error) any {
+	return core.AsGitError(err)
+}
+
+// emitEvent 向前端推送事件，应用尚未启动时忽略
+func (a *App) emitEvent(name string, data interface{}) {
+	if a.ctx == nil {
+		return
+	}
+	runtime.EventsEmit(a.ctx, name, data)
 }
 
 func (a *App) domReady(ctx context.Context) {
@@ -322,4 +867,8 @@ func (a *App) domReady(ctx context.Context) {
 
 // shutdown is called at application termination
 func (a *App) shutdown(ctx context.Context) {
+	if a.credentialBridge != nil {
+		core.SetCredentialBridge(nil)
+		a.credentialBridge.Close()
+	}
 }
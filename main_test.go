@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-git-client-window/core"
+)
+
+// bindingCallPattern 匹配前端对 Go 绑定的调用起点
+var bindingCallPattern = regexp.MustCompile(`window\.go\.main\.App\.(\w+)\(`)
+
+// TestFrontendBindings 检查前端调用的每个绑定都由 App 导出，且参数个数一致（Wails 会拒绝个数不符的调用）
+func TestFrontendBindings(t *testing.T) {
+	files, err := filepath.Glob("frontend/src/*.vue")
+	require.NoError(t, err)
+	components, err := filepath.Glob("frontend/src/components/*.vue")
+	require.NoError(t, err)
+	files = append(files, components...)
+	require.NotEmpty(t, files)
+
+	appType := reflect.TypeOf(&App{})
+	calls := 0
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		source := string(data)
+
+		for _, match := range bindingCallPattern.FindAllStringSubmatchIndex(source, -1) {
+			name := source[match[2]:match[3]]
+			args := countCallArgs(source[match[1]:])
+			calls++
+
+			method, ok := appType.MethodByName(name)
+			if !assert.True(t, ok, "%s: App.%s is not exported", file, name) {
+				continue
+			}
+			// 第一个入参为接收者
+			assert.Equal(t, method.Type.NumIn()-1, args, "%s: App.%s called with wrong number of arguments", file, name)
+		}
+	}
+	assert.NotZero(t, calls)
+}
+
+// countCallArgs 统计调用参数个数，source 从左括号之后开始
+func countCallArgs(source string) int {
+	depth, commas, hasArgs := 0, 0, false
+	for _, r := range source {
+		switch {
+		case r == ')' && depth == 0:
+			if !hasArgs {
+				return 0
+			}
+			return commas + 1
+		case strings.ContainsRune("([{", r):
+			depth++
+		case strings.ContainsRune(")]}", r):
+			depth--
+		case r == ',' && depth == 0:
+			commas++
+		}
+		if !strings.ContainsRune(" \t\r\n", r) {
+			hasArgs = true
+		}
+	}
+	return commas + 1
+}
+
+func TestOperationResult(t *testing.T) {
+	result := operationResult(context.Background(), "op", "done", nil)
+	assert.True(t, result.Success)
+	assert.False(t, result.Canceled || result.TimedOut)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result = operationResult(ctx, "op", "", fmt.Errorf("stopped"))
+	assert.False(t, result.Success)
+	assert.True(t, result.Canceled)
+	assert.False(t, result.TimedOut)
+
+	// 超时不应被当作用户取消
+	ctx, cancel = context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	result = operationResult(ctx, "op", "", &core.GitError{Kind: core.ErrKindTimeout, Message: "operation timed out"})
+	assert.False(t, result.Canceled)
+	assert.True(t, result.TimedOut)
+	assert.Equal(t, string(core.ErrKindTimeout), result.ErrorKind)
+}
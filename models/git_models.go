@@ -6,9 +6,39 @@ import (
 
 // GitFileStatus 文件状态
 type GitFileStatus struct {
-	Filename string `json:"filename"`
-	Status   string `json:"status"` // M/A/D/R/?
-	Staged   bool   `json:"staged"`
+	Filename       string              `json:"filename"`
+	Status         string              `json:"status"` // M/A/D/R/?
+	Staged         bool                `json:"staged"`
+	IndexStatus    string              `json:"indexStatus"`            // 暂存区状态（porcelain X 位），无变化为 .
+	WorktreeStatus string              `json:"worktreeStatus"`         // 工作区状态（porcelain Y 位），无变化为 .
+	OrigFilename   string              `json:"origFilename,omitempty"` // 重命名/复制前的路径
+	Score          int                 `json:"score,omitempty"`        // 重命名/复制的相似度
+	Unmerged       bool                `json:"unmerged"`               // 是否处于冲突状态
+	Untracked      bool                `json:"untracked"`
+	Submodule      *GitSubmoduleStatus `json:"submodule,omitempty"` // 子模块状态，普通文件为 nil
+}
+
+// GitSubmoduleStatus 子模块状态
+type GitSubmoduleStatus struct {
+	CommitChanged    bool `json:"commitChanged"`    // 子模块指向的提交已变化
+	TrackedChanges   bool `json:"trackedChanges"`   // 子模块内有已跟踪文件的改动
+	UntrackedChanges bool `json:"untrackedChanges"` // 子模块内有未跟踪文件
+}
+
+// GitBranchStatus 当前分支状态（porcelain v2 的 branch 头信息）
+type GitBranchStatus struct {
+	OID      string `json:"oid"`      // HEAD 提交，空仓库为空
+	Head     string `json:"head"`     // 当前分支名，分离 HEAD 时为空
+	Detached bool   `json:"detached"` // 是否处于分离 HEAD
+	Upstream string `json:"upstream"` // 上游分支
+	Ahead    int    `json:"ahead"`
+	Behind   int    `json:"behind"`
+}
+
+// GitStatus 仓库状态
+type GitStatus struct {
+	Branch GitBranchStatus `json:"branch"`
+	Files  []GitFileStatus `json:"files"`
 }
 
 // GitCommitRecord 提交信息
@@ -18,6 +48,7 @@ type GitCommitRecord struct {
 	Author   string   `json:"author"`
 	Date     string   `json:"date"`
 	Branches []string `json:"branches"`
+	Tags     []string `json:"tags"`
 }
 
 func (c GitCommitRecord) String() string {
@@ -25,42 +56,655 @@ func (c GitCommitRecord) String() string {
 	return string(jsonString)
 }
 
+// GitLogQuery 提交日志查询条件
+type GitLogQuery struct {
+	Refs         []string `json:"refs"`         // 起始引用，为空时使用 HEAD
+	All          bool     `json:"all"`          // 查询所有引用（--all）
+	Skip         int      `json:"skip"`         // 跳过的提交数
+	After        string   `json:"after"`        // 游标：从该提交之后开始返回，优先于 Skip
+	Limit        int      `json:"limit"`        // 每页提交数
+	Author       string   `json:"author"`       // --author，支持正则
+	Committer    string   `json:"committer"`    // --committer，支持正则
+	Since        string   `json:"since"`        // --since，如 2024-01-01 或 "2 weeks ago"
+	Until        string   `json:"until"`        // --until
+	Grep         string   `json:"grep"`         // 按提交信息搜索（--grep）
+	IgnoreCase   bool     `json:"ignoreCase"`   // 搜索时忽略大小写
+	Pickaxe      string   `json:"pickaxe"`      // 按改动内容搜索（-S 或 -G）
+	PickaxeRegex bool     `json:"pickaxeRegex"` // 为 true 时使用 -G 正则匹配，否则使用 -S
+	Path         string   `json:"path"`         // 只显示修改该路径的提交
+	Follow       bool     `json:"follow"`       // 跟踪文件重命名（--follow），需要指定 Path
+	MergesOnly   bool     `json:"mergesOnly"`   // 只显示合并提交
+	NoMerges     bool     `json:"noMerges"`     // 排除合并提交
+	FirstParent  bool     `json:"firstParent"`  // 只沿第一父提交遍历
+}
+
+// GitLogEntry 提交日志中的一条完整记录
+type GitLogEntry struct {
+	Hash           string   `json:"hash"`
+	Parents        []string `json:"parents"`
+	AuthorName     string   `json:"authorName"`
+	AuthorEmail    string   `json:"authorEmail"`
+	AuthorDate     string   `json:"authorDate"` // ISO 8601
+	CommitterName  string   `json:"committerName"`
+	CommitterEmail string   `json:"committerEmail"`
+	CommitterDate  string   `json:"committerDate"` // ISO 8601
+	Subject        string   `json:"subject"`
+	Body           string   `json:"body"`
+	Message        string   `json:"message"` // 完整提交信息
+	Branches       []string `json:"branches"`
+	Tags           []string `json:"tags"`
+}
+
+// GitSignature 提交签名信息（%G?/%GS/%GK）
+type GitSignature struct {
+	Status string `json:"status"` // G 有效、B 无效、U 未知有效性、X 已过期、Y 密钥过期、R 密钥吊销、E 无法验证、N 无签名
+	Signer string `json:"signer"`
+	Key    string `json:"key"`
+}
+
+// GitCommitFile 提交中改动的文件及行数统计
+type GitCommitFile struct {
+	Filename   string `json:"filename"` // 新路径，删除的文件为旧路径
+	OldPath    string `json:"oldPath"`  // 重命名/复制前的路径
+	Status     string `json:"status"`   // A/M/D/R/C/T
+	Similarity int    `json:"similarity"`
+	Additions  int    `json:"additions"`
+	Deletions  int    `json:"deletions"`
+	Binary     bool   `json:"binary"` // 二进制文件没有行数统计
+}
+
+// GitCommitDetail 单个提交的详细信息
+type GitCommitDetail struct {
+	Commit             GitLogEntry     `json:"commit"`
+	AuthorTimestamp    int64           `json:"authorTimestamp"` // Unix 时间戳（秒）
+	AuthorTimezone     string          `json:"authorTimezone"`  // 如 +0800
+	CommitterTimestamp int64           `json:"committerTimestamp"`
+	CommitterTimezone  string          `json:"committerTimezone"`
+	Signature          GitSignature    `json:"signature"`
+	ComparedParent     string          `json:"comparedParent"` // 文件列表对比的父提交，根提交为空
+	Files              []GitCommitFile `json:"files"`
+	Additions          int             `json:"additions"`
+	Deletions          int             `json:"deletions"`
+}
+
+// GitCompareResult 两个引用的比较结果
+type GitCompareResult struct {
+	Base          string          `json:"base"`
+	Head          string          `json:"head"`
+	BaseHash      string          `json:"baseHash"`
+	HeadHash      string          `json:"headHash"`
+	MergeBase     string          `json:"mergeBase"`     // 无共同祖先时为空
+	Ahead         int             `json:"ahead"`         // head 独有的提交数
+	Behind        int             `json:"behind"`        // base 独有的提交数
+	AheadCommits  []GitLogEntry   `json:"aheadCommits"`  // head 独有的提交（最多 CompareCommitLimit 条）
+	BehindCommits []GitLogEntry   `json:"behindCommits"` // base 独有的提交（最多 CompareCommitLimit 条）
+	Files         []GitCommitFile `json:"files"`         // 三点比较：merge-base 到 head 的改动
+	TwoDotFiles   []GitCommitFile `json:"twoDotFiles"`   // 两点比较：base 到 head 的改动
+	Additions     int             `json:"additions"`     // Files 的新增行数合计
+	Deletions     int             `json:"deletions"`     // Files 的删除行数合计
+}
+
+// GitLogPage 提交日志分页结果
+type GitLogPage struct {
+	Commits    []GitLogEntry `json:"commits"`
+	HasMore    bool          `json:"hasMore"`
+	NextCursor string        `json:"nextCursor"` // 下一页的 After 参数
+}
+
+// GitGraphOptions 提交图查询选项
+type GitGraphOptions struct {
+	Refs  []string `json:"refs"`  // 起始引用，为空时使用 HEAD
+	All   bool     `json:"all"`   // 包含所有引用（--all）
+	Skip  int      `json:"skip"`  // 跳过的行数，用于分页
+	Limit int      `json:"limit"` // 每页行数
+}
+
+// GitGraphEdge 提交图中的一段连线，From/To 为列号
+type GitGraphEdge struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+// GitGraphRow 提交图中的一行
+// EdgesIn 为行顶部到提交节点所在高度的连线，EdgesOut 为节点到行底部的连线
+type GitGraphRow struct {
+	Commit   GitCommitRecord `json:"commit"`
+	Parents  []string        `json:"parents"`
+	Lane     int             `json:"lane"`  // 提交节点所在列
+	Width    int             `json:"width"` // 本行占用的列数
+	IsMerge  bool            `json:"isMerge"`
+	EdgesIn  []GitGraphEdge  `json:"edgesIn"`
+	EdgesOut []GitGraphEdge  `json:"edgesOut"`
+}
+
+// GitGraphPage 提交图分页结果
+type GitGraphPage struct {
+	Rows    []GitGraphRow `json:"rows"`
+	Skip    int           `json:"skip"`
+	HasMore bool          `json:"hasMore"` // 之后是否还有提交
+}
+
 // GitBranch 分支信息
 type GitBranch struct {
+	Name           string `json:"name"`
+	Current        bool   `json:"current"`
+	Remote         bool   `json:"remote"`
+	Tracked        string `json:"tracked"`        // 跟踪的远程分支，如 origin/main
+	UpstreamRemote string `json:"upstreamRemote"` // 上游所在的远程仓库
+	Ahead          int    `json:"ahead"`          // 领先上游的提交数
+	Behind         int    `json:"behind"`         // 落后上游的提交数
+	UpstreamGone   bool   `json:"upstreamGone"`   // 上游分支已在远程删除
+	Hash           string `json:"hash"`           // 最新提交
+	Subject        string `json:"subject"`        // 最新提交标题
+	Author         string `json:"author"`         // 最新提交作者
+	Date           string `json:"date"`           // 最新提交时间
+}
+
+// GitBranchDeleteResult 批量删除分支时单个分支的结果
+type GitBranchDeleteResult struct {
+	Name    string `json:"name"`
+	Deleted bool   `json:"deleted"`
+	Error   string `json:"error"`
+}
+
+// 重置模式
+const (
+	ResetSoft  = "soft"
+	ResetMixed = "mixed"
+	ResetHard  = "hard"
+)
+
+// GitTag 标签信息
+type GitTag struct {
+	Name         string `json:"name"`
+	Target       string `json:"target"`       // 标签最终指向的提交
+	Object       string `json:"object"`       // 附注标签的标签对象哈希，轻量标签与 Target 相同
+	Annotated    bool   `json:"annotated"`    // 是否为附注标签
+	Tagger       string `json:"tagger"`       // 附注标签的创建者
+	TaggerEmail  string `json:"taggerEmail"`  // 创建者邮箱
+	Date         string `json:"date"`         // 附注标签为创建时间，轻量标签为提交时间
+	Message      string `json:"message"`      // 附注标签的完整说明
+	HasSignature bool   `json:"hasSignature"` // 标签说明中是否附带 GPG/SSH 签名，仅表示存在签名，未校验其有效性
+}
+
+// GitTagCreateOptions 创建标签参数
+type GitTagCreateOptions struct {
 	Name    string `json:"name"`
-	Current bool   `json:"current"`
-	Remote  bool   `json:"remote"`
-	Tracked string `json:"tracked"` // 跟踪的远程分支
+	Target  string `json:"target"`  // 目标提交，为空表示 HEAD
+	Message string `json:"message"` // 非空时创建附注标签
+	Sign    bool   `json:"sign"`    // 创建签名标签（-s），必须同时提供 Message
+	Force   bool   `json:"force"`   // 覆盖同名标签
 }
 
+// 标签排序方式
+const (
+	TagSortVersion = "version" // 按版本号降序
+	TagSortDate    = "date"    // 按创建时间降序
+	TagSortName    = "name"    // 按名称升序
+)
+
+// GitCherryPickOptions cherry-pick / revert 参数
+type GitCherryPickOptions struct {
+	Commits      []string `json:"commits"`      // 提交或范围（如 a..b），按给定顺序应用
+	RecordOrigin bool     `json:"recordOrigin"` // -x，在提交信息中记录来源（仅 cherry-pick）
+	NoCommit     bool     `json:"noCommit"`     // --no-commit，只修改工作区和暂存区
+	Mainline     int      `json:"mainline"`     // -m，合并提交以第几个父提交为主线，0 表示不指定
+}
+
+// GitSequencerState cherry-pick / revert 的进行中状态
+type GitSequencerState struct {
+	Operation     string   `json:"operation"`     // cherry-pick 或 revert，无进行中操作时为空
+	InProgress    bool     `json:"inProgress"`    // 是否有进行中的操作
+	CurrentCommit string   `json:"currentCommit"` // 正在应用的提交
+	Remaining     []string `json:"remaining"`     // 尚未应用的 todo 行，如 "pick abc123 message"
+	Conflicts     []string `json:"conflicts"`     // 冲突文件
+}
+
+// 序列操作类型
+const (
+	SequencerCherryPick = "cherry-pick"
+	SequencerRevert     = "revert"
+)
+
+// GitRepoState 仓库当前进行中的操作
+type GitRepoState struct {
+	Operation      string   `json:"operation"`      // 见 RepoOperation* 常量
+	Step           int      `json:"step"`           // 当前步骤（rebase/am），从 1 开始，未知为 0
+	Total          int      `json:"total"`          // 总步骤数
+	HeadName       string   `json:"headName"`       // 正在操作的分支，如 rebase 中的原分支
+	Onto           string   `json:"onto"`           // rebase 的目标提交
+	OntoName       string   `json:"ontoName"`       // rebase 目标对应的分支名（可解析时）
+	CurrentCommit  string   `json:"currentCommit"`  // 正在处理的提交，如 MERGE_HEAD/REBASE_HEAD/CHERRY_PICK_HEAD
+	MergeBranch    string   `json:"mergeBranch"`    // 被合并的分支（merge 时）
+	Conflicts      []string `json:"conflicts"`      // 冲突文件
+	AllowedActions []string `json:"allowedActions"` // 可执行的操作，见 RepoAction* 常量
+}
+
+// 仓库进行中的操作类型
+const (
+	RepoOperationNone              = "none"
+	RepoOperationMerge             = "merge"
+	RepoOperationRebase            = "rebase"
+	RepoOperationRebaseInteractive = "rebase-interactive"
+	RepoOperationApplyMailbox      = "am"
+	RepoOperationCherryPick        = "cherry-pick"
+	RepoOperationRevert            = "revert"
+	RepoOperationBisect            = "bisect"
+)
+
+// 进行中操作允许的动作
+const (
+	RepoActionContinue = "continue"
+	RepoActionAbort    = "abort"
+	RepoActionSkip     = "skip"
+)
+
+// GitMergeOptions 合并参数
+type GitMergeOptions struct {
+	Branch          string   `json:"branch"`
+	FastForward     string   `json:"fastForward"`     // 快进策略，见 MergeFF* 常量，为空使用 git 默认行为
+	Squash          bool     `json:"squash"`          // --squash，合并结果只写入暂存区
+	NoCommit        bool     `json:"noCommit"`        // --no-commit，合并后不自动提交
+	Strategy        string   `json:"strategy"`        // -s，如 ort、recursive、ours
+	StrategyOptions []string `json:"strategyOptions"` // -X，如 ours、theirs、ignore-space-change
+	Message         string   `json:"message"`         // 自定义合并提交信息
+}
+
+// 合并的快进策略
+const (
+	MergeFFDefault = ""
+	MergeFFNever   = "no-ff"
+	MergeFFOnly    = "ff-only"
+)
+
+// GitMergePreview 合并预演结果
+type GitMergePreview struct {
+	Branch          string   `json:"branch"`
+	Head            string   `json:"head"`            // 当前 HEAD 提交
+	Target          string   `json:"target"`          // 被合并分支的提交
+	MergeBase       string   `json:"mergeBase"`       // 共同祖先
+	AlreadyUpToDate bool     `json:"alreadyUpToDate"` // 被合并分支已包含在当前分支中
+	FastForward     bool     `json:"fastForward"`     // 可以快进
+	Conflicts       bool     `json:"conflicts"`       // 合并会产生冲突
+	ConflictFiles   []string `json:"conflictFiles"`   // 冲突文件
+	Tree            string   `json:"tree"`            // 合并结果的树对象（含冲突标记）
+}
+
+// GitConflictRegion 工作区文件中的一个冲突区域
+type GitConflictRegion struct {
+	Index       int    `json:"index"`
+	StartLine   int    `json:"startLine"`   // <<<<<<< 所在行（从 1 开始）
+	EndLine     int    `json:"endLine"`     // >>>>>>> 所在行
+	Ours        string `json:"ours"`        // 当前分支一侧的内容
+	Base        string `json:"base"`        // 共同祖先内容，仅 diff3/zdiff3 风格时存在
+	Theirs      string `json:"theirs"`      // 合入一侧的内容
+	HasBase     bool   `json:"hasBase"`     // 是否包含 ||||||| 段
+	OursLabel   string `json:"oursLabel"`   // <<<<<<< 后的标签
+	BaseLabel   string `json:"baseLabel"`   // ||||||| 后的标签
+	TheirsLabel string `json:"theirsLabel"` // >>>>>>> 后的标签
+}
+
+// GitConflictFile 冲突文件的三方内容（对应暂存区 stage 1/2/3）
+type GitConflictFile struct {
+	Filename  string              `json:"filename"`
+	Base      string              `json:"base"`
+	Ours      string              `json:"ours"`
+	Theirs    string              `json:"theirs"`
+	HasBase   bool                `json:"hasBase"`   // 存在 stage 1，双方新增同名文件时为 false
+	HasOurs   bool                `json:"hasOurs"`   // 存在 stage 2，当前分支删除文件时为 false
+	HasTheirs bool                `json:"hasTheirs"` // 存在 stage 3，合入一侧删除文件时为 false
+	Binary    bool                `json:"binary"`    // 二进制文件不返回内容和冲突区域
+	Content   string              `json:"content"`   // 工作区当前内容（含冲突标记）
+	Regions   []GitConflictRegion `json:"regions"`
+}
+
+// GitConflictChoice 单个冲突区域的解决方式
+type GitConflictChoice struct {
+	Region  int    `json:"region"`  // 对应 GitConflictRegion.Index
+	Choice  string `json:"choice"`  // 见 ConflictChoice* 常量
+	Content string `json:"content"` // Choice 为 custom 时使用的内容
+}
+
+// GitConflictResolution 冲突文件的解决方案
+// Content 不为空时直接作为文件内容写入，否则按 Choices 逐个区域解决
+type GitConflictResolution struct {
+	Filename string              `json:"filename"`
+	Content  *string             `json:"content"` // 手工编辑的完整内容，可为空字符串；与 Choices 二选一
+	Choices  []GitConflictChoice `json:"choices"` // 按区域选择
+}
+
+// 冲突区域解决方式
+const (
+	ConflictChoiceOurs       = "ours"
+	ConflictChoiceTheirs     = "theirs"
+	ConflictChoiceBase       = "base"
+	ConflictChoiceOursTheirs = "ours-theirs" // 先保留 ours 再保留 theirs
+	ConflictChoiceTheirsOurs = "theirs-ours" // 先保留 theirs 再保留 ours
+	ConflictChoiceCustom     = "custom"
+)
+
+// GitRebaseTodoItem 交互式变基计划中的一项
+type GitRebaseTodoItem struct {
+	Action  string `json:"action"`  // 见 RebaseAction* 常量
+	Commit  string `json:"commit"`  // 提交哈希，exec 时为空
+	Subject string `json:"subject"` // 提交标题，仅用于展示
+	Message string `json:"message"` // reword/squash 时使用的新提交信息，squash 为空时保留合并后的默认信息
+	Command string `json:"command"` // exec 时执行的命令
+}
+
+// GitRebasePlan 交互式变基计划
+type GitRebasePlan struct {
+	Base       string              `json:"base"`       // 变基起点（不含），如 main 或 HEAD~5
+	Items      []GitRebaseTodoItem `json:"items"`      // 按执行顺序排列的计划，为空时使用 git 生成的默认计划
+	Autosquash bool                `json:"autosquash"` // --autosquash，自动整理 fixup!/squash! 提交
+	Autostash  bool                `json:"autostash"`  // --autostash，变基前自动 stash 工作区改动
+}
+
+// 交互式变基动作
+const (
+	RebaseActionPick   = "pick"
+	RebaseActionReword = "reword"
+	RebaseActionEdit   = "edit"
+	RebaseActionSquash = "squash"
+	RebaseActionFixup  = "fixup"
+	RebaseActionDrop   = "drop"
+	RebaseActionExec   = "exec"
+)
+
 // GitStash Stash 信息
 type GitStash struct {
-	ID      string `json:"id"`
+	ID      string `json:"id"`    // reflog 选择器，如 stash@{0}
+	Index   int    `json:"index"` // stash 序号
+	Hash    string `json:"hash"`
 	Message string `json:"message"`
-	Branch  string `json:"branch"`
+	Branch  string `json:"branch"` // 创建 stash 时所在的分支
 	Date    string `json:"date"`
 }
 
+// GitStashPushOptions stash push 参数
+type GitStashPushOptions struct {
+	Message          string   `json:"message"`
+	Paths            []string `json:"paths"`            // 仅 stash 指定路径
+	KeepIndex        bool     `json:"keepIndex"`        // --keep-index，保留暂存区内容
+	IncludeUntracked bool     `json:"includeUntracked"` // --include-untracked
+	Staged           bool     `json:"staged"`           // --staged，仅 stash 暂存区内容
+}
+
 // GitBlameLine Blame 行信息
+// 在 GitBlame 中 Author/Date/Message 留空，提交信息通过 GitBlame.Commits[Hash] 获取
 type GitBlameLine struct {
-	Line    int    `json:"line"`
-	Hash    string `json:"hash"`
-	Author  string `json:"author"`
-	Date    string `json:"date"`
-	Message string `json:"message"`
-	Content string `json:"content"`
+	Line     int    `json:"line"`
+	OrigLine int    `json:"origLine"` // 在该提交中的原始行号
+	Filename string `json:"filename"` // 在该提交中的原始文件名
+	Hash     string `json:"hash"`
+	Author   string `json:"author,omitempty"`
+	Date     string `json:"date,omitempty"`
+	Message  string `json:"message,omitempty"`
+	Content  string `json:"content"`
+}
+
+// GitBlameCommit Blame 中引用的提交信息
+type GitBlameCommit struct {
+	Hash             string `json:"hash"`
+	Author           string `json:"author"`
+	AuthorMail       string `json:"authorMail"`
+	AuthorTime       int64  `json:"authorTime"` // Unix 时间戳
+	AuthorTZ         string `json:"authorTz"`   // 如 +0800
+	Committer        string `json:"committer"`
+	CommitterMail    string `json:"committerMail"`
+	CommitterTime    int64  `json:"committerTime"`
+	CommitterTZ      string `json:"committerTz"`
+	Summary          string `json:"summary"`
+	Boundary         bool   `json:"boundary"`         // 是否为边界提交
+	Previous         string `json:"previous"`         // 该行上一次修改所在的提交
+	PreviousFilename string `json:"previousFilename"` // 上一次修改时的文件名
+}
+
+// GitBlame 文件的 Blame 结果
+type GitBlame struct {
+	Filename string                    `json:"filename"`
+	Revision string                    `json:"revision"`
+	Commits  map[string]GitBlameCommit `json:"commits"`
+	Lines    []GitBlameLine            `json:"lines"`
+}
+
+// GitBlameGroup 增量 Blame 输出的一组连续行
+type GitBlameGroup struct {
+	Hash      string `json:"hash"`
+	OrigLine  int    `json:"origLine"`
+	FinalLine int    `json:"finalLine"`
+	NumLines  int    `json:"numLines"`
+	Filename  string `json:"filename"`
+}
+
+// GitBlameEvent 增量 Blame 推送给前端的事件
+type GitBlameEvent struct {
+	OperationID string          `json:"operationId"`
+	Group       GitBlameGroup   `json:"group"`
+	Commit      *GitBlameCommit `json:"commit,omitempty"` // 仅在提交首次出现时携带
+}
+
+// GitBlameOptions Blame 参数
+type GitBlameOptions struct {
+	Revision         string `json:"revision"`         // 在指定版本上 blame，为空表示工作区
+	StartLine        int    `json:"startLine"`        // 起始行（-L），0 表示从头开始
+	EndLine          int    `json:"endLine"`          // 结束行（-L），0 表示到文件末尾
+	IgnoreWhitespace bool   `json:"ignoreWhitespace"` // -w
+	DetectMoves      bool   `json:"detectMoves"`      // -M，检测文件内移动的行
+	DetectCopies     bool   `json:"detectCopies"`     // -C，检测从其他文件复制的行
+	IgnoreRevsFile   string `json:"ignoreRevsFile"`   // --ignore-revs-file
 }
 
 // GitRemoteInfo 远程仓库信息
 type GitRemoteInfo struct {
-	Name string `json:"name"`
-	URL  string `json:"url"`
+	Name          string   `json:"name"`
+	URL           string   `json:"url"`           // 与 FetchURL 相同，保留以兼容旧接口
+	FetchURL      string   `json:"fetchUrl"`      // remote.<name>.url
+	PushURL       string   `json:"pushUrl"`       // remote.<name>.pushurl，未设置时与 FetchURL 相同
+	FetchRefspecs []string `json:"fetchRefspecs"` // remote.<name>.fetch
+	PushRefspecs  []string `json:"pushRefspecs"`  // remote.<name>.push
+}
+
+// GitFetchOptions 获取参数
+type GitFetchOptions struct {
+	Remote   string   `json:"remote"`   // 远程仓库名，为空时由 git 决定（通常为当前分支的上游远程）
+	Refspecs []string `json:"refspecs"` // 要获取的引用，为空时使用远程配置的 fetch refspec
+	All      bool     `json:"all"`      // --all，获取所有远程仓库
+	Prune    bool     `json:"prune"`    // --prune，删除远程已不存在的跟踪分支
+}
+
+// GitPullOptions 拉取参数
+type GitPullOptions struct {
+	Remote            string `json:"remote"`            // 远程仓库名，为空且未指定 Branch 时使用上游配置
+	Branch            string `json:"branch"`            // 要拉取的远程分支或 refspec，指定时 Remote 默认为 origin
+	Strategy          string `json:"strategy"`          // 整合方式，见 PullStrategy* 常量，为空时使用 git 配置
+	Autostash         bool   `json:"autostash"`         // --autostash，拉取前自动 stash 工作区改动
+	Prune             bool   `json:"prune"`             // --prune，删除远程已不存在的跟踪分支
+	RecurseSubmodules bool   `json:"recurseSubmodules"` // --recurse-submodules，同时更新子模块
+}
+
+// 拉取的整合方式
+const (
+	PullStrategyDefault = ""
+	PullStrategyMerge   = "merge"
+	PullStrategyRebase  = "rebase"
+	PullStrategyFFOnly  = "ff-only"
+)
+
+// 拉取结果
+const (
+	PullOutcomeUpToDate    = "up-to-date"
+	PullOutcomeFastForward = "fast-forward"
+	PullOutcomeMerge       = "merge"
+	PullOutcomeRebase      = "rebase"
+)
+
+// GitUpdatedRef 拉取或获取后发生变化的引用
+type GitUpdatedRef struct {
+	Ref     string `json:"ref"`     // 如 refs/remotes/origin/main、refs/tags/v1.0
+	OldHash string `json:"oldHash"` // 新增的引用为空
+	NewHash string `json:"newHash"` // 被清理的引用为空
+}
+
+// GitPullResult 拉取结果
+type GitPullResult struct {
+	Outcome     string          `json:"outcome"` // 见 PullOutcome* 常量
+	Remote      string          `json:"remote"`
+	Branch      string          `json:"branch"`  // 合入的远程引用，如 refs/heads/main
+	OldHead     string          `json:"oldHead"` // 拉取前的 HEAD，空仓库为空
+	NewHead     string          `json:"newHead"`
+	UpdatedRefs []GitUpdatedRef `json:"updatedRefs"` // 远程跟踪分支与标签的变化
+	Output      string          `json:"output"`
+}
+
+// GitPushOptions 推送参数
+type GitPushOptions struct {
+	Remote             string   `json:"remote"`             // 远程仓库名，为空且未指定 Refspecs 时推送到上游
+	Refspecs           []string `json:"refspecs"`           // 如 main、HEAD:refs/heads/feature，指定时 Remote 默认为 origin
+	Force              string   `json:"force"`              // 强制推送方式，见 PushForce* 常量
+	ExpectedRemoteHash string   `json:"expectedRemoteHash"` // with-lease 时远程分支应处于的提交（通常取自推送预览），为空时以远程跟踪分支为准
+	ForceIfIncludes    bool     `json:"forceIfIncludes"`    // --force-if-includes，要求远程跟踪分支的改动已被本地包含
+}
+
+// 强制推送方式
+const (
+	PushForceNone      = ""
+	PushForceWithLease = "with-lease" // --force-with-lease，远程分支与预期不符时拒绝
+	PushForceAlways    = "force"      // --force，无条件覆盖
+)
+
+// GitPushRefPreview 推送预览中单个目标分支的情况
+type GitPushRefPreview struct {
+	Source               string        `json:"source"`      // 本地引用，删除远程分支时为空
+	Destination          string        `json:"destination"` // 远程引用，如 refs/heads/main
+	LocalHash            string        `json:"localHash"`
+	RemoteHash           string        `json:"remoteHash"`           // 远程当前指向的提交，远程不存在该分支时为空
+	NewBranch            bool          `json:"newBranch"`            // 远程尚无该分支
+	Delete               bool          `json:"delete"`               // 删除远程分支
+	FastForward          bool          `json:"fastForward"`          // 推送为快进，不会覆盖任何提交
+	Protected            bool          `json:"protected"`            // 命中受保护分支规则，禁止强制推送
+	Overwritten          []GitLogEntry `json:"overwritten"`          // 强制推送后远程将丢失的提交
+	MissingRemoteCommits bool          `json:"missingRemoteCommits"` // 远程提交尚未获取到本地，无法列出被覆盖的提交
+}
+
+// GitPushPreview 推送预览
+type GitPushPreview struct {
+	Remote string              `json:"remote"`
+	Refs   []GitPushRefPreview `json:"refs"`
 }
 
 // FileDiff 文件差异信息
 type FileDiff struct {
-	Filename   string   `json:"filename"`
-	OldContent string   `json:"oldContent"`
-	NewContent string   `json:"newContent"`
-	Changes    []string `json:"changes"`
+	Filename   string     `json:"filename"`   // 展示用路径，删除的文件为旧路径
+	OldPath    string     `json:"oldPath"`    // 旧路径，新增文件为空
+	NewPath    string     `json:"newPath"`    // 新路径，删除文件为空
+	Status     string     `json:"status"`     // A/M/D/R/C
+	OldMode    string     `json:"oldMode"`    // 旧文件模式，如 100644
+	NewMode    string     `json:"newMode"`    // 新文件模式
+	Similarity int        `json:"similarity"` // 重命名/复制的相似度
+	Binary     bool       `json:"binary"`     // 是否为二进制文件
+	Additions  int        `json:"additions"`
+	Deletions  int        `json:"deletions"`
+	Hunks      []DiffHunk `json:"hunks"`
+}
+
+// DiffHunk 差异块
+type DiffHunk struct {
+	Header   string     `json:"header"`   // 完整的 @@ 行
+	OldStart int        `json:"oldStart"` // 旧文件起始行
+	OldLines int        `json:"oldLines"` // 旧文件行数
+	NewStart int        `json:"newStart"` // 新文件起始行
+	NewLines int        `json:"newLines"` // 新文件行数
+	Section  string     `json:"section"`  // @@ 之后的函数/段落上下文
+	Lines    []DiffLine `json:"lines"`
+}
+
+// DiffLine 差异行
+type DiffLine struct {
+	Kind      string `json:"kind"`      // context/add/delete
+	Content   string `json:"content"`   // 不含 +/-/空格 前缀
+	OldLine   int    `json:"oldLine"`   // 旧文件行号，新增行为 0
+	NewLine   int    `json:"newLine"`   // 新文件行号，删除行为 0
+	NoNewline bool   `json:"noNewline"` // 该行末尾没有换行符
+}
+
+// 差异行类型
+const (
+	DiffLineContext = "context"
+	DiffLineAdd     = "add"
+	DiffLineDelete  = "delete"
+)
+
+// DiffSelection 在某个文件的差异中选中的内容，下标对应 FileDiff.Hunks 与 DiffHunk.Lines
+type DiffSelection struct {
+	Filename string        `json:"filename"`
+	Hunks    []int         `json:"hunks"` // 整块选中的差异块
+	Lines    []DiffLineRef `json:"lines"` // 单独选中的行
+}
+
+// DiffLineRef 差异行的位置
+type DiffLineRef struct {
+	Hunk int `json:"hunk"`
+	Line int `json:"line"`
+}
+
+// GitProgress 远程操作（clone/fetch/pull/push）的进度信息
+type GitProgress struct {
+	OperationID string `json:"operationId"`
+	Remote      bool   `json:"remote"`      // 是否为服务端（remote: 前缀）输出的进度
+	Phase       string `json:"phase"`       // 阶段，如 Receiving objects、Resolving deltas
+	Percent     int    `json:"percent"`     // 百分比，未知时为 -1
+	Current     int64  `json:"current"`     // 已处理数量
+	Total       int64  `json:"total"`       // 总数量，未知时为 0
+	Transferred string `json:"transferred"` // 已传输数据量，如 1.20 MiB
+	Speed       string `json:"speed"`       // 传输速度，如 500.00 KiB/s
+	Done        bool   `json:"done"`        // 该阶段是否已完成
+}
+
+// GitOperationResult 长时间操作结束时的通知
+type GitOperationResult struct {
+	OperationID string `json:"operationId"`
+	Success     bool   `json:"success"`
+	Canceled    bool   `json:"canceled"` // 被用户取消
+	TimedOut    bool   `json:"timedOut"` // 超过操作的超时时间
+	Output      string `json:"output"`
+	Error       string `json:"error"`
+	ErrorKind   string `json:"errorKind"` // 对应 core.GitErrorKind
+}
+
+// GitCredential git credential 协议中的凭据
+type GitCredential struct {
+	Protocol string `json:"protocol"`
+	Host     string `json:"host"`
+	Path     string `json:"path"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// 凭据请求类型
+const (
+	CredentialKindCredential = "credential" // 来自 credential helper，需要用户名和密码/令牌
+	CredentialKindAskpass    = "askpass"    // 来自 GIT_ASKPASS/SSH_ASKPASS，如 SSH 私钥密码或主机指纹确认
+)
+
+// GitCredentialRequest 推送给前端的凭据请求
+type GitCredentialRequest struct {
+	ID          string `json:"id"`
+	Kind        string `json:"kind"`     // 见 CredentialKind* 常量
+	Prompt      string `json:"prompt"`   // askpass 的提示语
+	Secret      bool   `json:"secret"`   // 输入内容是否需要隐藏
+	Protocol    string `json:"protocol"` // 以下为 credential 请求的目标
+	Host        string `json:"host"`
+	Path        string `json:"path"`
+	Username    string `json:"username"`    // 已知的用户名，如 URL 中携带的
+	CanRemember bool   `json:"canRemember"` // 凭据存储可用（系统钥匙串可用）时才能记住凭据
+}
+
+// GitCredentialResponse 前端对凭据请求的回复
+type GitCredentialResponse struct {
+	Username string `json:"username"`
+	Password string `json:"password"` // 密码或访问令牌
+	Answer   string `json:"answer"`   // askpass 请求的回答
+	Remember bool   `json:"remember"` // 认证成功后保存到加密的本地凭据存储
+	Canceled bool   `json:"canceled"`
 }